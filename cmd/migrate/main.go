@@ -0,0 +1,18 @@
+// Comando migrate: gerencia as migrações de banco de dados pela linha de comando.
+//
+// Uso:
+//
+//	migrate [flags] <comando> [argumentos]
+//
+// Execute "migrate -h" para ver os comandos e flags disponíveis.
+package main
+
+import (
+	"os"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,416 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drift"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+	"github.com/LuisMarchio03/golang_migration_system/internal/exec"
+)
+
+// Códigos de saída do comando migrate, estáveis para uso em scripts.
+const (
+	ExitOK    = 0 // Comando executado com sucesso
+	ExitError = 1 // Falha ao executar o comando (conexão, migração com erro, lock não obtido etc.)
+	ExitUsage = 2 // Uso inválido: comando, argumento ou flag desconhecidos
+	ExitDrift = 3 // O comando drift encontrou divergências entre o banco de dados e as migrações
+)
+
+const usage = `Uso: migrate [flags] <comando> [argumentos]
+
+Comandos:
+  create <nome>      cria um par de arquivos de migração vazios (up e down)
+  up [N] [-dry-run]  aplica todas as migrações pendentes, ou apenas as N próximas; com -dry-run, apenas
+                     mostra as migrações e os comandos que seriam executados
+  down [N]           reverte a última migração aplicada, ou as N últimas
+  status             lista as migrações e a situação de cada uma: aplicada, pendente, com arquivo
+                     ausente, com arquivo alterado ou suja (interrompida ou com falha)
+  version            mostra a versão atual do banco de dados
+  goto <versão>      aplica ou reverte migrações até chegar exatamente à versão
+  force <versão>     registra o banco como estando na versão, sem executar migrações
+  baseline           cria uma migração com a estrutura atual do banco de dados
+  drift              compara o banco de dados com a estrutura resultante das migrações
+
+Todas as flags podem ser definidas pelas variáveis de ambiente indicadas entre colchetes.
+
+Flags:
+`
+
+// options reúne as flags globais do comando migrate.
+type options struct {
+	driver      string
+	dir         string
+	lockTimeout time.Duration
+	cfg         config.Cfg
+	shadowDSN   string
+	format      string
+}
+
+// Run executa o comando migrate com os argumentos informados (sem o nome do programa),
+// escrevendo a saída em stdout e os erros em stderr. Retorna o código de saída do processo.
+func Run(args []string, stdout, stderr io.Writer) int {
+	var opts options
+
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&opts.driver, "driver", env("MIGRATE_DRIVER", "sqlite"), "driver do banco de dados: "+strings.Join(drivers.Names(), ", ")+" [MIGRATE_DRIVER]")
+	flags.StringVar(&opts.cfg.DSN, "dsn", env("MIGRATE_DSN", ""), "string de conexão completa; substitui as demais flags de conexão [MIGRATE_DSN]")
+	flags.StringVar(&opts.cfg.User, "user", env("MIGRATE_USER", ""), "usuário do banco de dados [MIGRATE_USER]")
+	flags.StringVar(&opts.cfg.Passwd, "password", env("MIGRATE_PASSWORD", ""), "senha do banco de dados [MIGRATE_PASSWORD]")
+	flags.StringVar(&opts.cfg.Net, "net", env("MIGRATE_NET", "tcp"), "protocolo de rede [MIGRATE_NET]")
+	flags.StringVar(&opts.cfg.Addr, "addr", env("MIGRATE_ADDR", ""), "endereço do servidor [MIGRATE_ADDR]")
+	flags.StringVar(&opts.cfg.Port, "port", env("MIGRATE_PORT", ""), "porta do servidor [MIGRATE_PORT]")
+	flags.StringVar(&opts.cfg.Path, "path", env("MIGRATE_PATH", "migrate.db"), "arquivo do banco de dados SQLite, ou :memory: [MIGRATE_PATH]")
+	flags.StringVar(&opts.cfg.DBName, "dbname", env("MIGRATE_DBNAME", ""), "nome do banco de dados [MIGRATE_DBNAME]")
+	flags.StringVar(&opts.dir, "dir", env("MIGRATE_DIR", "migrations"), "diretório de migrações [MIGRATE_DIR]")
+	flags.StringVar(&opts.shadowDSN, "shadow-dsn", env("MIGRATE_SHADOW_DSN", ""), "string de conexão de um banco vazio e descartável, usado pelo comando drift; no SQLite, o padrão é um banco em memória [MIGRATE_SHADOW_DSN]")
+	flags.StringVar(&opts.format, "format", env("MIGRATE_FORMAT", "text"), "formato da saída dos comandos status, drift e up -dry-run: text ou json [MIGRATE_FORMAT]")
+
+	defaultTimeout, err := time.ParseDuration(env("MIGRATE_LOCK_TIMEOUT", exec.DefaultLockTimeout.String()))
+	if err != nil {
+		fmt.Fprintln(stderr, "Valor inválido em MIGRATE_LOCK_TIMEOUT:", err)
+		return ExitUsage
+	}
+	flags.DurationVar(&opts.lockTimeout, "lock-timeout", defaultTimeout, "tempo máximo de espera pelo lock de migração [MIGRATE_LOCK_TIMEOUT]")
+
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return ExitUsage
+	}
+	if opts.format != "text" && opts.format != "json" {
+		return usageError(stderr, "formato inválido: "+opts.format)
+	}
+	command, commandArgs := flags.Arg(0), flags.Args()[1:]
+	switch command {
+	case "create":
+		if len(commandArgs) != 1 {
+			return usageError(stderr, "create exige o nome da migração")
+		}
+		fileName, err := exec.CreateMigration(opts.dir, commandArgs[0])
+		if err != nil {
+			return failure(stderr, err)
+		}
+		fmt.Fprintln(stdout, "Migração criada:", fileName)
+		return ExitOK
+
+	case "up", "down":
+		steps := 0
+		if command == "down" {
+			steps = 1
+		}
+		dryRun := false
+		if command == "up" {
+			commandArgs, dryRun = extractFlag(commandArgs, "dry-run")
+		}
+		if len(commandArgs) > 1 {
+			return usageError(stderr, command+" aceita no máximo um argumento")
+		}
+		if len(commandArgs) == 1 {
+			n, err := strconv.Atoi(commandArgs[0])
+			if err != nil || n <= 0 {
+				return usageError(stderr, "N deve ser um número inteiro positivo")
+			}
+			steps = n
+		}
+		return withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			if dryRun {
+				plan, err := m.PlanSteps(ctx, steps)
+				if err != nil {
+					return err
+				}
+				return printPlan(stdout, opts.format, plan)
+			}
+			if command == "up" {
+				return m.UpSteps(ctx, steps)
+			}
+			return m.Down(ctx, steps)
+		})
+
+	case "status":
+		if len(commandArgs) != 0 {
+			return usageError(stderr, "status não aceita argumentos")
+		}
+		return withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			status, err := m.Status(ctx)
+			if err != nil {
+				return err
+			}
+			return printStatus(stdout, opts.format, status)
+		})
+
+	case "version":
+		if len(commandArgs) != 0 {
+			return usageError(stderr, "version não aceita argumentos")
+		}
+		return withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			version, err := m.Version(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(stdout, version)
+			return nil
+		})
+
+	case "goto", "force":
+		if len(commandArgs) != 1 {
+			return usageError(stderr, command+" exige a versão")
+		}
+		version, err := strconv.ParseInt(commandArgs[0], 10, 64)
+		if err != nil || version < 0 {
+			return usageError(stderr, "versão inválida: "+commandArgs[0])
+		}
+		return withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			if command == "goto" {
+				return m.Goto(ctx, version)
+			}
+			return m.Force(ctx, version)
+		})
+
+	case "baseline":
+		if len(commandArgs) != 0 {
+			return usageError(stderr, "baseline não aceita argumentos")
+		}
+		return withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			schemas, err := m.Introspect(ctx)
+			if err != nil {
+				return err
+			}
+			if len(schemas) == 0 {
+				return fmt.Errorf("O banco de dados não possui tabelas")
+			}
+			fileName, err := m.GenerateMigration(schemas...)
+			if err != nil {
+				return err
+			}
+			// As tabelas já existem: a migração deve ser registrada como aplicada, e não executada
+			fmt.Fprintln(stdout, "Migração criada:", fileName)
+			fmt.Fprintln(stdout, "Revise o arquivo e registre-o como aplicado com 'migrate force <versão>'.")
+			return nil
+		})
+
+	case "drift":
+		if len(commandArgs) != 0 {
+			return usageError(stderr, "drift não aceita argumentos")
+		}
+		shadowCfg := config.Cfg{DSN: opts.shadowDSN}
+		if opts.shadowDSN == "" {
+			if !isSQLite(opts.driver) {
+				return usageError(stderr, "drift exige um banco de dados vazio em -shadow-dsn ou MIGRATE_SHADOW_DSN")
+			}
+			shadowCfg.Path = ":memory:"
+		}
+
+		var differences []drift.Difference
+		code := withMigrator(opts, stdout, stderr, func(ctx context.Context, m *exec.Migrator) error {
+			shadow, err := exec.ConfigDB(opts.driver, shadowCfg)
+			if err != nil {
+				return fmt.Errorf("Erro ao conectar ao banco de dados de comparação: %v", err)
+			}
+			defer shadow.Close()
+
+			differences, err = m.DetectDrift(ctx, shadow)
+			if err != nil {
+				return err
+			}
+			return printDrift(stdout, opts.format, differences)
+		})
+		if code == ExitOK && len(differences) > 0 {
+			return ExitDrift
+		}
+		return code
+
+	default:
+		return usageError(stderr, "comando desconhecido: "+command)
+	}
+}
+
+// withMigrator conecta ao banco de dados configurado pelas flags e executa fn com um Migrator das migrações
+// do diretório, que escreve o andamento em stdout. Ao final, fecha a conexão.
+// Retorna o código de saída correspondente ao resultado.
+func withMigrator(opts options, stdout, stderr io.Writer, fn func(ctx context.Context, m *exec.Migrator) error) int {
+	if opts.driver == "" {
+		return usageError(stderr, "informe o driver do banco de dados com -driver ou MIGRATE_DRIVER")
+	}
+
+	db, err := exec.ConfigDB(opts.driver, opts.cfg)
+	if err != nil {
+		return failure(stderr, err)
+	}
+	defer db.Close()
+	driver, _ := drivers.Lookup(opts.driver)
+
+	m, err := exec.New(
+		exec.WithDB(db),
+		exec.WithDriver(driver),
+		exec.WithDir(opts.dir),
+		exec.WithLogger(log.New(stdout, "", 0)),
+		exec.WithLockTimeout(opts.lockTimeout),
+	)
+	if err != nil {
+		return failure(stderr, err)
+	}
+	if err := fn(context.Background(), m); err != nil {
+		return failure(stderr, err)
+	}
+	return ExitOK
+}
+
+// stateLabels são os nomes das situações das migrações na saída em texto do comando status.
+var stateLabels = map[exec.MigrationState]string{
+	exec.StateApplied:          "aplicada",
+	exec.StatePending:          "pendente",
+	exec.StateMissingFile:      "arquivo ausente",
+	exec.StateChecksumMismatch: "arquivo alterado",
+	exec.StateDirty:            "suja",
+}
+
+// statusJSON é a representação de uma migração na saída em JSON do comando status.
+type statusJSON struct {
+	Version    int64               `json:"version"`
+	Name       string              `json:"name"`
+	State      exec.MigrationState `json:"state"`
+	AppliedAt  *time.Time          `json:"applied_at,omitempty"`
+	DurationMs *int64              `json:"duration_ms,omitempty"`
+}
+
+// printStatus escreve a situação das migrações em forma de tabela ou em JSON.
+func printStatus(w io.Writer, format string, status []exec.MigrationStatus) error {
+	if format == "json" {
+		rows := []statusJSON{}
+		for _, s := range status {
+			row := statusJSON{Version: s.Version, Name: s.Name, State: s.State}
+			if s.State != exec.StatePending {
+				appliedAt, duration := s.AppliedAt.UTC(), s.ExecutionTime.Milliseconds()
+				row.AppliedAt, row.DurationMs = &appliedAt, &duration
+			}
+			rows = append(rows, row)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSÃO\tNOME\tSITUAÇÃO\tAPLICADA EM\tDURAÇÃO")
+	for _, s := range status {
+		appliedAt, duration := "-", "-"
+		if s.State != exec.StatePending {
+			appliedAt, duration = s.AppliedAt.Local().Format("2006-01-02 15:04:05"), s.ExecutionTime.String()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", s.Version, s.Name, stateLabels[s.State], appliedAt, duration)
+	}
+	return tw.Flush()
+}
+
+// printDrift escreve as divergências encontradas pelo comando drift, em texto ou em JSON.
+func printDrift(w io.Writer, format string, differences []drift.Difference) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(differences)
+	}
+
+	if len(differences) == 0 {
+		fmt.Fprintln(w, "Nenhuma divergência: o banco de dados corresponde às migrações.")
+		return nil
+	}
+	for _, d := range differences {
+		fmt.Fprintln(w, "-", d)
+	}
+	fmt.Fprintf(w, "%d divergência(s) entre o banco de dados e as migrações.\n", len(differences))
+	return nil
+}
+
+// printPlan escreve as migrações que seriam executadas pelo comando up -dry-run, em texto ou em JSON.
+// O texto lista os comandos de cada migração precedidos de comentários SQL.
+func printPlan(w io.Writer, format string, plan []exec.PlannedMigration) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+
+	if len(plan) == 0 {
+		fmt.Fprintln(w, "Nenhuma migração pendente.")
+		return nil
+	}
+	for _, m := range plan {
+		mode := "sem transação"
+		if m.Transactional {
+			mode = "em transação"
+		}
+		fmt.Fprintf(w, "-- Migração %s (versão %d, %s)\n", m.Name, m.Version, mode)
+		if m.Go {
+			fmt.Fprintln(w, "-- Migração Go: os comandos são definidos pela função registrada")
+		}
+		for i, statement := range m.Statements {
+			fmt.Fprintf(w, "-- Comando %d (linha %d)\n%s;\n", i+1, statement.Line, statement.SQL)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%d migração(ões) seriam aplicadas.\n", len(plan))
+	return nil
+}
+
+// isSQLite indica se o nome do driver se refere ao SQLite.
+func isSQLite(driver string) bool {
+	return strings.EqualFold(driver, "sqlite") || strings.EqualFold(driver, "sqlite3")
+}
+
+// extractFlag remove dos argumentos de um comando a flag booleana informada (-name ou --name), em qualquer
+// posição, e indica se ela estava presente.
+func extractFlag(args []string, name string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, arg := range args {
+		if arg == "-"+name || arg == "--"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// usageError informa um uso inválido do comando e retorna ExitUsage.
+func usageError(stderr io.Writer, message string) int {
+	fmt.Fprintln(stderr, "Uso inválido:", message)
+	fmt.Fprintln(stderr, "Execute 'migrate -h' para ver os comandos disponíveis.")
+	return ExitUsage
+}
+
+// failure informa o erro de execução de um comando e retorna ExitError.
+func failure(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, "Erro:", err)
+	return ExitError
+}
+
+// env retorna o valor da variável de ambiente, ou fallback se ela não estiver definida.
+func env(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return fallback
+}
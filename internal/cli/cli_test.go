@@ -0,0 +1,196 @@
+package cli_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunCreate(t *testing.T) {
+	migrationsDir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+
+	// Executa o comando create
+	code := cli.Run([]string{"-dir", migrationsDir, "create", "Add Users"}, &stdout, &stderr)
+	assert.Equal(t, cli.ExitOK, code, stderr.String())
+
+	// Verifica se os arquivos up e down foram criados
+	up, _ := filepath.Glob(filepath.Join(migrationsDir, "migration_*_add_users.up.sql"))
+	down, _ := filepath.Glob(filepath.Join(migrationsDir, "migration_*_add_users.down.sql"))
+	assert.Len(t, up, 1, "Arquivo up não foi criado")
+	assert.Len(t, down, 1, "Arquivo down não foi criado")
+
+	// Migrações criadas no mesmo segundo recebem versões diferentes, e o diretório continua utilizável
+	for _, name := range []string{"a", "b", "c"} {
+		assert.Equal(t, cli.ExitOK, cli.Run([]string{"-dir", migrationsDir, "create", name}, &stdout, &stderr), stderr.String())
+	}
+	up, _ = filepath.Glob(filepath.Join(migrationsDir, "migration_*.up.sql"))
+	assert.Len(t, up, 4)
+	flags := []string{"-driver", "sqlite", "-path", filepath.Join(t.TempDir(), "test.db"), "-dir", migrationsDir}
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "status"), &stdout, &stderr), stderr.String())
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	// Comandos e argumentos inválidos devem falhar com ExitUsage, antes de acessar o banco de dados
+	cases := [][]string{
+		{},
+		{"unknown"},
+		{"create"},
+		{"up", "abc"},
+		{"goto"},
+		{"status", "extra"},
+		{"down", "0"},
+		{"baseline", "extra"},
+		{"-format", "xml", "drift"},
+	}
+	for _, args := range cases {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, cli.ExitUsage, cli.Run(args, &stdout, &stderr), "argumentos: %v", args)
+	}
+}
+
+func TestRunDrift(t *testing.T) {
+	migrationsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	assert.NoError(t, os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.up.sql"), []byte("CREATE TABLE users (id INTEGER NOT NULL);\n"), 0644))
+	flags := []string{"-driver", "sqlite", "-path", dbPath, "-dir", migrationsDir}
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "up"), &stdout, &stderr), stderr.String())
+
+	// Sem alterações manuais, o banco de dados corresponde às migrações
+	stdout.Reset()
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "drift"), &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "Nenhuma divergência")
+
+	// Uma coluna acrescentada manualmente é apontada em JSON, com o código de saída ExitDrift
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NoError(t, err)
+	_, err = db.Exec("ALTER TABLE users ADD COLUMN email TEXT")
+	assert.NoError(t, err)
+	db.Close()
+
+	stdout.Reset()
+	assert.Equal(t, cli.ExitDrift, cli.Run(append(flags, "-format", "json", "drift"), &stdout, &stderr), stderr.String())
+	var differences []map[string]string
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &differences))
+	assert.Equal(t, []map[string]string{{"kind": "extra-column", "table": "users", "name": "email", "actual": "TEXT"}}, differences)
+}
+
+func TestRunUpDryRun(t *testing.T) {
+	migrationsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	assert.NoError(t, os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.up.sql"), []byte("CREATE TABLE users (id INTEGER);\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(migrationsDir, "migration_20240102000000.up.sql"),
+		[]byte("-- migrate:no-transaction\nINSERT INTO users VALUES (1);\n\nINSERT INTO users VALUES (2);\n"), 0644))
+	flags := []string{"-driver", "sqlite", "-path", dbPath, "-dir", migrationsDir}
+
+	// O plano lista as migrações pendentes e os seus comandos, sem executá-los
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "up", "-dry-run"), &stdout, &stderr), stderr.String())
+	assert.Equal(t, `-- Migração migration_20240101000000.up.sql (versão 20240101000000, em transação)
+-- Comando 1 (linha 1)
+CREATE TABLE users (id INTEGER);
+
+-- Migração migration_20240102000000.up.sql (versão 20240102000000, sem transação)
+-- Comando 1 (linha 2)
+INSERT INTO users VALUES (1);
+-- Comando 2 (linha 4)
+INSERT INTO users VALUES (2);
+
+2 migração(ões) seriam aplicadas.
+`, stdout.String())
+
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("SELECT version FROM schema_migrations")
+	assert.Error(t, err, "O plano não deve criar a tabela de histórico")
+
+	// Depois de aplicar a primeira migração, apenas a segunda fica no plano, também em JSON
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "up", "1"), &stdout, &stderr), stderr.String())
+	stdout.Reset()
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "-format", "json", "up", "--dry-run", "1"), &stdout, &stderr), stderr.String())
+	var plan []struct {
+		Version       int64
+		Transactional bool
+		Statements    []struct {
+			SQL  string
+			Line int
+		}
+	}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &plan))
+	if assert.Len(t, plan, 1) {
+		assert.Equal(t, int64(20240102000000), plan[0].Version)
+		assert.False(t, plan[0].Transactional)
+		assert.Len(t, plan[0].Statements, 2)
+	}
+}
+
+func TestRunStatus(t *testing.T) {
+	migrationsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	write := func(version string, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(migrationsDir, "migration_"+version+".up.sql"), []byte(content), 0644))
+	}
+	write("20240101000000", "CREATE TABLE users (id INTEGER);")
+	write("20240102000000", "CREATE TABLE posts (id INTEGER);")
+	write("20240103000000", "CREATE TABLE tags (id INTEGER);")
+	flags := []string{"-driver", "sqlite", "-path", dbPath, "-dir", migrationsDir}
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "up"), &stdout, &stderr), stderr.String())
+
+	// Uma migração alterada, uma removida, uma interrompida (suja) e uma pendente
+	write("20240102000000", "CREATE TABLE posts (id INTEGER, title TEXT);")
+	assert.NoError(t, os.Remove(filepath.Join(migrationsDir, "migration_20240103000000.up.sql")))
+	write("20240104000000", "INSERT INTO missing VALUES (1);")
+	write("20240105000000", "CREATE TABLE comments (id INTEGER);")
+	db, err := sql.Open("sqlite3", dbPath)
+	assert.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("INSERT INTO schema_migrations (version, name, applied_at, execution_time, success, dirty) VALUES (20240104000000, 'migration_20240104000000.up.sql', CURRENT_TIMESTAMP, 3, 0, 1)")
+	assert.NoError(t, err)
+
+	stdout.Reset()
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "-format", "json", "status"), &stdout, &stderr), stderr.String())
+	var status []struct {
+		Version    int64
+		State      string
+		AppliedAt  *string `json:"applied_at"`
+		DurationMs *int64  `json:"duration_ms"`
+	}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &status))
+	var states []string
+	for _, s := range status {
+		states = append(states, s.State)
+		assert.Equal(t, s.State != "pending", s.AppliedAt != nil && s.DurationMs != nil, "versão %d", s.Version)
+	}
+	assert.Equal(t, []string{"applied", "checksum-mismatch", "missing-file", "dirty", "pending"}, states)
+
+	// A saída em texto é uma tabela com as mesmas situações
+	stdout.Reset()
+	assert.Equal(t, cli.ExitOK, cli.Run(append(flags, "status"), &stdout, &stderr), stderr.String())
+	assert.Contains(t, stdout.String(), "DURAÇÃO")
+	for _, label := range []string{"aplicada", "arquivo alterado", "arquivo ausente", "suja", "pendente"} {
+		assert.Contains(t, stdout.String(), label)
+	}
+}
+
+func TestRunStatusUnreachableDatabase(t *testing.T) {
+	migrationsDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.up.sql"), []byte("CREATE TABLE users (id INTEGER);"), 0644))
+
+	// Um banco de dados inacessível é uma falha, e não um banco sem migrações aplicadas
+	var stdout, stderr bytes.Buffer
+	flags := []string{"-driver", "sqlite", "-path", filepath.Join(t.TempDir(), "missing", "test.db"), "-dir", migrationsDir}
+	assert.Equal(t, cli.ExitError, cli.Run(append(flags, "status"), &stdout, &stderr))
+	assert.NotContains(t, stdout.String(), "pendente")
+	assert.NotEmpty(t, stderr.String())
+}
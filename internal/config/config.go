@@ -1,5 +1,12 @@
 package config
 
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 // Configuração do banco de dados
 type Cfg struct {
 	User     string
@@ -10,11 +17,151 @@ type Cfg struct {
 	Port     string
 	Keyspace string
 	Service  string
+	DSN      string // String de conexão completa; quando informada, substitui os demais campos
+	Path     string // Caminho do arquivo do banco de dados (SQLite), ou ":memory:" para um banco em memória
 }
 
 // Schema representa um esquema de tabela
 type Schema struct {
 	DbType    string
 	TableName string
-	Fields    map[string]string // Mapa de nome de campo para tipo de dados
+	Columns   []Column          // Colunas da tabela, geradas exatamente nesta ordem
+	Fields    map[string]string // Mapa de nome de campo para tipo de dados (veja OrderedColumns)
+
+	Indexes           []Index
+	UniqueConstraints []UniqueConstraint
+	ForeignKeys       []ForeignKey
+	Checks            []Check
+}
+
+// Column representa uma coluna de tabela. A coluna pode ser descrita por uma definição livre em Type,
+// escrita para um banco específico, ou pelos campos tipados, que são traduzidos para o dialeto de cada banco.
+type Column struct {
+	Name          string
+	RenamedFrom   string   // Nome anterior da coluna, quando ela foi renomeada (usado na comparação de schemas)
+	Type          string   // Definição livre da coluna, como "INT NOT NULL PRIMARY KEY"; quando informada, os campos abaixo são ignorados
+	DataType      DataType // Tipo lógico da coluna
+	Length        int      // Tamanho de String e Binary
+	Precision     int      // Número total de dígitos de Decimal
+	Scale         int      // Número de casas decimais de Decimal
+	Nullable      bool     // Indica se a coluna aceita NULL; por padrão a coluna é NOT NULL
+	Default       string   // Expressão SQL do valor padrão, como "0", "'ativo'" ou "CURRENT_TIMESTAMP"
+	PrimaryKey    bool
+	AutoIncrement bool
+	Unique        bool
+	Comment       string
+}
+
+// DataType é o tipo lógico de uma coluna, independente do banco de dados.
+type DataType string
+
+// Tipos lógicos de coluna. Um DataType que não está nesta lista é usado como foi escrito.
+const (
+	SmallInt  DataType = "smallint"
+	Integer   DataType = "integer"
+	BigInt    DataType = "bigint"
+	Decimal   DataType = "decimal" // Usa Precision e Scale
+	Float     DataType = "float"   // Ponto flutuante de precisão dupla
+	Boolean   DataType = "boolean"
+	String    DataType = "string" // Texto de tamanho variável limitado a Length (255 por padrão)
+	Text      DataType = "text"   // Texto sem limite de tamanho
+	Date      DataType = "date"
+	Timestamp DataType = "timestamp" // Data e hora
+	Binary    DataType = "binary"    // Dados binários; limitados a Length quando informado
+	UUID      DataType = "uuid"
+	JSON      DataType = "json"
+)
+
+// DefaultStringLength é o tamanho usado nas colunas String sem Length.
+const DefaultStringLength = 255
+
+// Index representa um índice da tabela. Sem Name, o nome idx_<tabela>_<colunas> é usado (veja Schema.IndexName).
+type Index struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// UniqueConstraint representa uma restrição UNIQUE sobre uma ou mais colunas. Sem Name, o nome
+// uq_<tabela>_<colunas> é usado (veja Schema.UniqueName).
+type UniqueConstraint struct {
+	Name    string
+	Columns []string
+}
+
+// ForeignKey representa uma chave estrangeira. Sem Name, o nome fk_<tabela>_<colunas> é usado
+// (veja Schema.ForeignKeyName).
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string // Ação ao remover a linha referenciada: "CASCADE", "SET NULL", "RESTRICT", ...
+	OnUpdate   string // Ação ao alterar a chave referenciada
+}
+
+// Check representa uma restrição CHECK. Sem Name, o nome ck_<tabela>_<posição> é usado (veja Schema.CheckName).
+type Check struct {
+	Name       string
+	Expression string // Expressão SQL, como "price >= 0"
+}
+
+// IndexName retorna o nome do índice, ou o nome padrão quando não informado.
+func (s Schema) IndexName(index Index) string {
+	return defaultName(index.Name, "idx", s.TableName, strings.Join(index.Columns, "_"))
+}
+
+// UniqueName retorna o nome da restrição UNIQUE, ou o nome padrão quando não informado.
+func (s Schema) UniqueName(unique UniqueConstraint) string {
+	return defaultName(unique.Name, "uq", s.TableName, strings.Join(unique.Columns, "_"))
+}
+
+// ForeignKeyName retorna o nome da chave estrangeira, ou o nome padrão quando não informado.
+func (s Schema) ForeignKeyName(fk ForeignKey) string {
+	return defaultName(fk.Name, "fk", s.TableName, strings.Join(fk.Columns, "_"))
+}
+
+// CheckName retorna o nome da restrição CHECK na posição i de Checks, ou o nome padrão quando não informado.
+func (s Schema) CheckName(i int) string {
+	return defaultName(s.Checks[i].Name, "ck", s.TableName, strconv.Itoa(i+1))
+}
+
+// defaultName retorna name, quando informado, ou o nome <prefixo>_<tabela>_<sufixo>.
+func defaultName(name string, prefix string, table string, suffix string) string {
+	if name != "" {
+		return name
+	}
+	return prefix + "_" + table + "_" + suffix
+}
+
+// primaryKeyPattern localiza a declaração de chave primária em uma definição de coluna.
+var primaryKeyPattern = regexp.MustCompile(`(?i)\bPRIMARY\s+KEY\b`)
+
+// OrderedColumns retorna as colunas do schema na ordem em que devem ser geradas: primeiro as de Columns,
+// na ordem informada, e depois as de Fields que não estão em Columns. Como a ordem de um mapa não é
+// definida, as colunas de Fields seguem uma regra estável: as declaradas como PRIMARY KEY primeiro
+// e, em cada grupo, em ordem alfabética.
+func (s Schema) OrderedColumns() []Column {
+	columns := make([]Column, 0, len(s.Columns)+len(s.Fields))
+	listed := make(map[string]bool, len(s.Columns))
+	for _, column := range s.Columns {
+		columns = append(columns, column)
+		listed[column.Name] = true
+	}
+
+	fields := make([]Column, 0, len(s.Fields))
+	for name, fieldType := range s.Fields {
+		if !listed[name] {
+			fields = append(fields, Column{Name: name, Type: fieldType})
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		pi, pj := primaryKeyPattern.MatchString(fields[i].Type), primaryKeyPattern.MatchString(fields[j].Type)
+		if pi != pj {
+			return pi
+		}
+		return fields[i].Name < fields[j].Name
+	})
+
+	return append(columns, fields...)
 }
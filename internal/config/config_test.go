@@ -0,0 +1,48 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedColumns(t *testing.T) {
+	// Colunas informadas em Columns mantêm exatamente a ordem declarada
+	schema := config.Schema{
+		Columns: []config.Column{
+			{Name: "username", Type: "VARCHAR(50)"},
+			{Name: "id", Type: "INT PRIMARY KEY"},
+			{Name: "email", Type: "VARCHAR(100)"},
+		},
+	}
+	assert.Equal(t, []string{"username", "id", "email"}, columnNames(schema.OrderedColumns()))
+
+	// Colunas de Fields seguem a regra estável: chave primária primeiro, depois ordem alfabética
+	schema = config.Schema{
+		Fields: map[string]string{
+			"username": "VARCHAR(50)",
+			"email":    "VARCHAR(100)",
+			"id":       "INT NOT NULL AUTO_INCREMENT PRIMARY KEY",
+		},
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []string{"id", "email", "username"}, columnNames(schema.OrderedColumns()))
+	}
+
+	// Com os dois informados, as colunas de Fields vêm depois e as repetidas são ignoradas
+	schema.Columns = []config.Column{{Name: "username", Type: "VARCHAR(80)"}}
+	assert.Equal(t, []config.Column{
+		{Name: "username", Type: "VARCHAR(80)"},
+		{Name: "id", Type: "INT NOT NULL AUTO_INCREMENT PRIMARY KEY"},
+		{Name: "email", Type: "VARCHAR(100)"},
+	}, schema.OrderedColumns())
+}
+
+func columnNames(columns []config.Column) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// ReviewComment retorna um comentário SQL que marca um ponto do script a ser revisado manualmente,
+// usado quando uma alteração não pode ser gerada com segurança.
+func ReviewComment(format string, args ...interface{}) string {
+	return "-- Revisar: " + fmt.Sprintf(format, args...) + "\n"
+}
+
+// typedChange indica se a alteração entre as duas versões da coluna pode ser decomposta em tipo,
+// nulidade e valor padrão, o que só é possível quando as duas são colunas tipadas.
+func typedChange(from config.Column, to config.Column) bool {
+	return from.Type == "" && to.Type == ""
+}
+
+// rawAlterReview retorna o comentário de revisão de uma alteração de coluna com definição livre (Column.Type),
+// que não pode ser decomposta nas cláusulas de ALTER COLUMN.
+func rawAlterReview(table string, from config.Column, to config.Column) string {
+	return ReviewComment("altere manualmente a coluna %s.%s de %q para %q", table, to.Name, definitionOf(from), definitionOf(to))
+}
+
+// definitionOf descreve a coluna para os comentários de revisão.
+func definitionOf(column config.Column) string {
+	if column.Type != "" {
+		return column.Type
+	}
+	return strings.TrimSpace(typedColumn(Generic, column, ""))
+}
+
+// alterColumnClauses retorna as cláusulas ALTER COLUMN do padrão SQL, aceitas pelo PostgreSQL e pelo Firebird,
+// que levam a coluna de from para to: TYPE, SET/DROP NOT NULL e SET/DROP DEFAULT, apenas para o que mudou.
+func alterColumnClauses(d Dialect, from config.Column, to config.Column) []string {
+	column := Ident(d, to.Name)
+	var clauses []string
+	if d.ColumnType(from) != d.ColumnType(to) {
+		clauses = append(clauses, fmt.Sprintf("ALTER COLUMN %s TYPE %s", column, d.ColumnType(to)))
+	}
+	if from.Nullable != to.Nullable {
+		if to.Nullable {
+			clauses = append(clauses, fmt.Sprintf("ALTER COLUMN %s DROP NOT NULL", column))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("ALTER COLUMN %s SET NOT NULL", column))
+		}
+	}
+	if from.Default != to.Default {
+		if to.Default == "" {
+			clauses = append(clauses, fmt.Sprintf("ALTER COLUMN %s DROP DEFAULT", column))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("ALTER COLUMN %s SET DEFAULT %s", column, to.Default))
+		}
+	}
+	return clauses
+}
+
+// alterTable monta um comando ALTER TABLE com as cláusulas informadas, ou "" quando não há cláusulas.
+func alterTable(d Dialect, table string, clauses []string) string {
+	if len(clauses) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s;\n", Ident(d, table), strings.Join(clauses, ", "))
+}
+
+// standardAlterColumn gera a alteração de coluna com as cláusulas do padrão SQL, ou o comentário de revisão
+// quando alguma das versões usa definição livre.
+func standardAlterColumn(d Dialect, table string, from config.Column, to config.Column) string {
+	if !typedChange(from, to) {
+		return rawAlterReview(table, from, to)
+	}
+	return alterTable(d, table, alterColumnClauses(d, from, to))
+}
+
+// addColumn monta o comando ALTER TABLE que acrescenta a coluna, com a cláusula do banco ("ADD COLUMN" ou "ADD").
+func addColumn(d Dialect, table string, clause string, column config.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s %s %s;\n", Ident(d, table), clause, columnDefinition(d, column))
+}
+
+// dropColumn monta o comando ALTER TABLE que remove a coluna, com a cláusula do banco ("DROP COLUMN" ou "DROP").
+func dropColumn(d Dialect, table string, clause string, name string) string {
+	return fmt.Sprintf("ALTER TABLE %s %s %s;\n", Ident(d, table), clause, Ident(d, name))
+}
+
+// renameColumn monta o comando ALTER TABLE ... RENAME COLUMN do padrão SQL.
+func renameColumn(d Dialect, table string, from string, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;\n", Ident(d, table), Ident(d, from), Ident(d, to))
+}
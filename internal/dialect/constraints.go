@@ -0,0 +1,160 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// ConstraintKind identifica o tipo de uma restrição de tabela.
+type ConstraintKind int
+
+const (
+	UniqueConstraint ConstraintKind = iota
+	CheckConstraint
+	ForeignKeyConstraint
+)
+
+// Constraint é uma restrição de tabela já escrita no dialeto: o nome e a definição que segue CONSTRAINT <nome>,
+// como "UNIQUE (email)" ou "CHECK (price >= 0)".
+type Constraint struct {
+	Kind       ConstraintKind
+	Name       string
+	Definition string
+}
+
+// actionRestricter é implementado pelos dialetos que aceitam apenas parte das ações ON DELETE e ON UPDATE
+// das chaves estrangeiras. Retorna as ações a serem geradas; "" omite a cláusula.
+type actionRestricter interface {
+	referentialActions(onDelete string, onUpdate string) (string, string)
+}
+
+// Constraints retorna as restrições UNIQUE e CHECK do schema, nesta ordem.
+func Constraints(d Dialect, schema config.Schema) []Constraint {
+	var constraints []Constraint
+	for _, unique := range schema.UniqueConstraints {
+		constraints = append(constraints, Constraint{
+			Kind:       UniqueConstraint,
+			Name:       schema.UniqueName(unique),
+			Definition: fmt.Sprintf("UNIQUE (%s)", identList(d, unique.Columns)),
+		})
+	}
+	for i, check := range schema.Checks {
+		constraints = append(constraints, Constraint{
+			Kind:       CheckConstraint,
+			Name:       schema.CheckName(i),
+			Definition: fmt.Sprintf("CHECK (%s)", check.Expression),
+		})
+	}
+	return constraints
+}
+
+// ForeignKeys retorna as chaves estrangeiras do schema.
+func ForeignKeys(d Dialect, schema config.Schema) []Constraint {
+	var constraints []Constraint
+	for _, fk := range schema.ForeignKeys {
+		definition := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s", identList(d, fk.Columns), Ident(d, fk.RefTable))
+		if len(fk.RefColumns) > 0 {
+			definition += fmt.Sprintf(" (%s)", identList(d, fk.RefColumns))
+		}
+
+		onDelete, onUpdate := strings.ToUpper(fk.OnDelete), strings.ToUpper(fk.OnUpdate)
+		if r, ok := d.(actionRestricter); ok {
+			onDelete, onUpdate = r.referentialActions(onDelete, onUpdate)
+		}
+		if onDelete != "" {
+			definition += " ON DELETE " + onDelete
+		}
+		if onUpdate != "" {
+			definition += " ON UPDATE " + onUpdate
+		}
+
+		constraints = append(constraints, Constraint{Kind: ForeignKeyConstraint, Name: schema.ForeignKeyName(fk), Definition: definition})
+	}
+	return constraints
+}
+
+// CreateConstraints retorna os comandos que criam as restrições UNIQUE e CHECK e os índices do schema. São
+// executados depois da criação de todas as tabelas e antes das chaves estrangeiras (veja CreateForeignKeys),
+// que podem depender das restrições UNIQUE de outras tabelas.
+func CreateConstraints(d Dialect, schema config.Schema) string {
+	statements := ""
+	for _, constraint := range Constraints(d, schema) {
+		statements += d.AddConstraint(schema.TableName, constraint)
+	}
+	for _, index := range schema.Indexes {
+		statements += d.CreateIndex(schema.TableName, schema.IndexName(index), index)
+	}
+	return statements
+}
+
+// DropConstraints retorna os comandos que removem os índices e as restrições criados por CreateConstraints,
+// na ordem inversa.
+func DropConstraints(d Dialect, schema config.Schema) string {
+	statements := ""
+	for i := len(schema.Indexes) - 1; i >= 0; i-- {
+		statements += d.DropIndex(schema.TableName, schema.IndexName(schema.Indexes[i]))
+	}
+	constraints := Constraints(d, schema)
+	for i := len(constraints) - 1; i >= 0; i-- {
+		statements += d.DropConstraint(schema.TableName, constraints[i])
+	}
+	return statements
+}
+
+// CreateForeignKeys retorna os comandos que criam as chaves estrangeiras do schema.
+func CreateForeignKeys(d Dialect, schema config.Schema) string {
+	statements := ""
+	for _, fk := range ForeignKeys(d, schema) {
+		statements += d.AddConstraint(schema.TableName, fk)
+	}
+	return statements
+}
+
+// DropForeignKeys retorna os comandos que removem as chaves estrangeiras do schema, na ordem inversa da criação.
+func DropForeignKeys(d Dialect, schema config.Schema) string {
+	statements := ""
+	fks := ForeignKeys(d, schema)
+	for i := len(fks) - 1; i >= 0; i-- {
+		statements += d.DropConstraint(schema.TableName, fks[i])
+	}
+	return statements
+}
+
+// restrictToNoAction converte a ação RESTRICT em NO ACTION, que impede a alteração da linha referenciada
+// da mesma forma nos bancos que não suportam RESTRICT.
+func restrictToNoAction(action string) string {
+	if action == "RESTRICT" {
+		return "NO ACTION"
+	}
+	return action
+}
+
+// identList retorna os identificadores separados por vírgula.
+func identList(d Dialect, names []string) string {
+	idents := make([]string, len(names))
+	for i, name := range names {
+		idents[i] = Ident(d, name)
+	}
+	return strings.Join(idents, ", ")
+}
+
+// addConstraint monta o comando ALTER TABLE ... ADD CONSTRAINT do padrão SQL.
+func addConstraint(d Dialect, table string, constraint Constraint) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s;\n", Ident(d, table), Ident(d, constraint.Name), constraint.Definition)
+}
+
+// dropConstraint monta o comando ALTER TABLE ... DROP CONSTRAINT do padrão SQL.
+func dropConstraint(d Dialect, table string, constraint Constraint) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;\n", Ident(d, table), Ident(d, constraint.Name))
+}
+
+// createIndex monta o comando CREATE INDEX, com a proteção informada em guard ("" ou "IF NOT EXISTS ").
+func createIndex(d Dialect, table string, name string, index config.Index, guard string) string {
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s%s ON %s (%s);\n", unique, guard, Ident(d, name), Ident(d, table), identList(d, index.Columns))
+}
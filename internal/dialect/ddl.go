@@ -0,0 +1,161 @@
+package dialect
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// simpleIdentifier corresponde aos identificadores que dispensam aspas em todos os bancos suportados.
+var simpleIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedWords são palavras reservadas comuns aos bancos suportados, que precisam de aspas quando usadas como nome.
+var reservedWords = map[string]bool{
+	"add": true, "all": true, "alter": true, "and": true, "as": true, "by": true, "check": true,
+	"column": true, "constraint": true, "create": true, "default": true, "delete": true, "desc": true,
+	"distinct": true, "drop": true, "from": true, "grant": true, "group": true, "having": true,
+	"index": true, "insert": true, "key": true, "not": true, "null": true, "or": true, "order": true,
+	"primary": true, "references": true, "select": true, "table": true, "to": true, "union": true,
+	"unique": true, "update": true, "user": true, "values": true, "where": true,
+}
+
+// autoIncrementPattern localiza a palavra-chave AUTO_INCREMENT (MySQL) em uma definição de coluna.
+var autoIncrementPattern = regexp.MustCompile(`(?i)\s*\bAUTO_?INCREMENT\b`)
+
+// Ident retorna o identificador como ele deve aparecer no DDL gerado. Identificadores simples ficam sem
+// aspas, para que continuem valendo as regras de maiúsculas e minúsculas de cada banco (o Firebird e o Oracle,
+// por exemplo, convertem nomes sem aspas para maiúsculas); os demais, como palavras reservadas e nomes com
+// espaços, recebem as aspas do dialeto.
+func Ident(d Dialect, name string) string {
+	if simpleIdentifier.MatchString(name) && !reservedWords[strings.ToLower(name)] {
+		return name
+	}
+	return d.QuoteIdent(name)
+}
+
+// quote envolve o nome com as aspas informadas, duplicando as aspas de fechamento contidas nele.
+func quote(name string, open string, close string) string {
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
+
+// columnDefinitions retorna as definições "nome tipo" das colunas do schema, na ordem de
+// config.Schema.OrderedColumns.
+func columnDefinitions(d Dialect, schema config.Schema) []string {
+	columns := schema.OrderedColumns()
+	definitions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		definitions = append(definitions, columnDefinition(d, column))
+	}
+	return definitions
+}
+
+// columnDefinition retorna a definição "nome tipo" da coluna.
+func columnDefinition(d Dialect, column config.Column) string {
+	return strings.TrimSpace(Ident(d, column.Name) + " " + d.ColumnDefinition(column))
+}
+
+// formatCreateTable monta o comando de criação de tabela, com uma coluna por linha.
+func formatCreateTable(create string, columns []string) string {
+	return fmt.Sprintf("%s (\n    %s\n);\n", create, strings.Join(columns, ",\n    "))
+}
+
+// typedColumn monta a definição de uma coluna tipada: o tipo do dialeto, a palavra-chave de auto incremento
+// informada em identity e as restrições DEFAULT, NOT NULL, PRIMARY KEY e UNIQUE, nesta ordem, aceita por
+// todos os bancos suportados.
+func typedColumn(d Dialect, column config.Column, identity string) string {
+	parts := []string{d.ColumnType(column)}
+	if column.AutoIncrement && identity != "" {
+		parts = append(parts, identity)
+	}
+	if column.Default != "" {
+		parts = append(parts, "DEFAULT "+column.Default)
+	}
+	if !column.Nullable {
+		parts = append(parts, "NOT NULL")
+	}
+	if column.PrimaryKey {
+		parts = append(parts, "PRIMARY KEY")
+	}
+	if column.Unique {
+		parts = append(parts, "UNIQUE")
+	}
+	return strings.Join(parts, " ")
+}
+
+// standardType retorna o tipo do padrão SQL correspondente ao tipo lógico da coluna, usado pelo dialeto
+// genérico e pelos dialetos nos tipos em que seguem o padrão. Um tipo lógico desconhecido é usado como foi escrito.
+func standardType(column config.Column) string {
+	switch column.DataType {
+	case config.SmallInt:
+		return "SMALLINT"
+	case config.Integer:
+		return "INTEGER"
+	case config.BigInt:
+		return "BIGINT"
+	case config.Decimal:
+		return SizedType("DECIMAL", column.Precision, column.Scale)
+	case config.Float:
+		return "DOUBLE PRECISION"
+	case config.Boolean:
+		return "BOOLEAN"
+	case config.String:
+		return SizedType("VARCHAR", stringLength(column), 0)
+	case config.Text:
+		return "TEXT"
+	case config.Date:
+		return "DATE"
+	case config.Timestamp:
+		return "TIMESTAMP"
+	case config.Binary:
+		return "BLOB"
+	case config.UUID:
+		return "CHAR(36)"
+	case config.JSON:
+		return "TEXT"
+	}
+	return string(column.DataType)
+}
+
+// SizedType acrescenta o tamanho, ou a precisão e a escala, ao nome do tipo. Valores zerados são omitidos.
+// Também é usada na leitura do catálogo (veja o pacote introspect), para que os tipos lidos e gerados coincidam.
+func SizedType(name string, size int, scale int) string {
+	switch {
+	case size > 0 && scale > 0:
+		return fmt.Sprintf("%s(%d,%d)", name, size, scale)
+	case size > 0:
+		return fmt.Sprintf("%s(%d)", name, size)
+	}
+	return name
+}
+
+// stringLength retorna o tamanho de uma coluna String, com DefaultStringLength quando não informado.
+func stringLength(column config.Column) int {
+	if column.Length > 0 {
+		return column.Length
+	}
+	return config.DefaultStringLength
+}
+
+// commentStatements retorna os comandos COMMENT ON COLUMN dos comentários das colunas, aceitos pelo
+// PostgreSQL, Firebird e Oracle.
+func commentStatements(d Dialect, schema config.Schema) string {
+	statements := ""
+	for _, column := range schema.OrderedColumns() {
+		if column.Type == "" && column.Comment != "" {
+			statements += fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;\n", Ident(d, schema.TableName), Ident(d, column.Name), stringLiteral(column.Comment))
+		}
+	}
+	return statements
+}
+
+// replaceAutoIncrement substitui a palavra-chave AUTO_INCREMENT pela sintaxe equivalente do banco.
+func replaceAutoIncrement(fieldType string, replacement string) string {
+	return autoIncrementPattern.ReplaceAllString(fieldType, " "+replacement)
+}
+
+// stringLiteral retorna o texto como um literal SQL entre aspas simples.
+func stringLiteral(text string) string {
+	return quote(text, "'", "'")
+}
@@ -0,0 +1,144 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// Dialect descreve as particularidades da linguagem SQL de um banco de dados.
+type Dialect interface {
+	// Name retorna o nome do dialeto, por exemplo "mysql" ou "postgresql".
+	Name() string
+	// Placeholder retorna o marcador do n-ésimo parâmetro (a partir de 1) de uma consulta.
+	Placeholder(n int) string
+	// TransactionalDDL indica se comandos DDL (CREATE, ALTER, DROP) são desfeitos em um ROLLBACK.
+	TransactionalDDL() bool
+	// TimestampType retorna o tipo de coluna usado para armazenar data e hora.
+	TimestampType() string
+	// BatchSeparator retorna o comando que, sozinho em uma linha, separa os lotes de um script e é tratado
+	// pelo cliente, como o GO do SQL Server. Retorna "" quando o banco não usa separador de lotes.
+	BatchSeparator() string
+	// QuoteIdent envolve um identificador (tabela, coluna) com as aspas do banco.
+	QuoteIdent(name string) string
+	// ColumnType retorna o tipo do banco correspondente ao tipo lógico (DataType), ao tamanho e à precisão da coluna.
+	ColumnType(column config.Column) string
+	// ColumnDefinition retorna a definição da coluna, sem o nome: o tipo e as restrições da coluna tipada ou a
+	// definição livre de Column.Type, adaptada ao banco.
+	ColumnDefinition(column config.Column) string
+	// CreateTable retorna o comando de criação da tabela descrita pelo schema, terminado por ";" e quebra de
+	// linha, com a proteção contra tabela já existente que o banco suportar.
+	CreateTable(schema config.Schema) string
+	// DropTable retorna o comando de remoção da tabela, terminado por ";" e quebra de linha, com a proteção
+	// contra tabela inexistente que o banco suportar.
+	DropTable(table string) string
+	// AddColumn retorna o comando que acrescenta a coluna à tabela.
+	AddColumn(table string, column config.Column) string
+	// DropColumn retorna o comando que remove a coluna da tabela.
+	DropColumn(table string, name string) string
+	// RenameColumn retorna o comando que renomeia a coluna from da tabela para to.
+	RenameColumn(table string, from string, to string) string
+	// AlterColumn retorna os comandos que alteram o tipo, a nulidade e o valor padrão da coluna de from para to,
+	// ou um comentário de revisão (veja ReviewComment) quando o banco não permite gerar a alteração com segurança.
+	AlterColumn(table string, from config.Column, to config.Column) string
+	// CreateIndex retorna o comando de criação do índice name na tabela.
+	CreateIndex(table string, name string, index config.Index) string
+	// DropIndex retorna o comando de remoção do índice name da tabela.
+	DropIndex(table string, name string) string
+	// AddConstraint retorna o comando que acrescenta a restrição à tabela, ou "" quando o banco só aceita
+	// restrições na criação da tabela e CreateTable já as inclui.
+	AddConstraint(table string, constraint Constraint) string
+	// DropConstraint retorna o comando que remove a restrição da tabela, ou "" quando o banco não permite removê-la.
+	DropConstraint(table string, constraint Constraint) string
+}
+
+// byName associa os nomes aceitos em Schema.DbType aos dialetos.
+var byName = map[string]Dialect{
+	"generic":     Generic,
+	"mysql":       MySQL,
+	"postgresql":  PostgreSQL,
+	"postgres":    PostgreSQL,
+	"sqlite":      SQLite,
+	"sqlite3":     SQLite,
+	"sqlserver":   SQLServer,
+	"mssql":       SQLServer,
+	"firebirdsql": Firebird,
+	"firebird":    Firebird,
+	"oracle":      Oracle,
+}
+
+// ByName retorna o dialeto com o nome informado, sem diferenciar maiúsculas de minúsculas.
+func ByName(name string) (Dialect, bool) {
+	d, ok := byName[strings.ToLower(name)]
+	return d, ok
+}
+
+// Generic é o dialeto usado para bancos sem dialeto próprio: parâmetros com "?", DDL não transacional,
+// identificadores entre aspas duplas e CREATE TABLE IF NOT EXISTS.
+var Generic Dialect = generic{}
+
+type generic struct{}
+
+func (generic) Name() string             { return "generic" }
+func (generic) Placeholder(n int) string { return "?" }
+func (generic) TransactionalDDL() bool   { return false }
+func (generic) TimestampType() string    { return "TIMESTAMP" }
+func (generic) BatchSeparator() string   { return "" }
+
+func (generic) QuoteIdent(name string) string { return quote(name, `"`, `"`) }
+
+func (generic) ColumnType(column config.Column) string { return standardType(column) }
+
+// ColumnDefinition gera as colunas auto incremento como identity do padrão SQL. Comentários de coluna não são gerados.
+func (g generic) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return column.Type
+	}
+	return typedColumn(g, column, "GENERATED BY DEFAULT AS IDENTITY")
+}
+
+func (g generic) CreateTable(schema config.Schema) string {
+	return formatCreateTable("CREATE TABLE IF NOT EXISTS "+Ident(g, schema.TableName), columnDefinitions(g, schema))
+}
+
+func (g generic) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", Ident(g, table))
+}
+
+func (g generic) AddColumn(table string, column config.Column) string {
+	return addColumn(g, table, "ADD COLUMN", column)
+}
+
+func (g generic) DropColumn(table string, name string) string {
+	return dropColumn(g, table, "DROP COLUMN", name)
+}
+
+func (g generic) RenameColumn(table string, from string, to string) string {
+	return renameColumn(g, table, from, to)
+}
+
+func (g generic) AlterColumn(table string, from config.Column, to config.Column) string {
+	return standardAlterColumn(g, table, from, to)
+}
+
+func (g generic) CreateIndex(table string, name string, index config.Index) string {
+	return createIndex(g, table, name, index, "IF NOT EXISTS ")
+}
+
+func (g generic) DropIndex(table string, name string) string {
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;\n", Ident(g, name))
+}
+
+func (g generic) AddConstraint(table string, constraint Constraint) string {
+	return addConstraint(g, table, constraint)
+}
+
+func (g generic) DropConstraint(table string, constraint Constraint) string {
+	return dropConstraint(g, table, constraint)
+}
+
+// numberedPlaceholder formata marcadores numerados, como $1 no PostgreSQL e @p1 no SQL Server.
+func numberedPlaceholder(prefix string, n int) string {
+	return fmt.Sprintf("%s%d", prefix, n)
+}
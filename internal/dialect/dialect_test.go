@@ -0,0 +1,131 @@
+package dialect_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+// update regrava os arquivos golden com a saída atual: go test ./internal/dialect -update
+var update = flag.Bool("update", false, "regrava os arquivos golden em testdata")
+
+// assertGolden compara a saída com o arquivo testdata/<name>.golden.
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		assert.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err, "Arquivo golden não encontrado; execute os testes com -update")
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestCreateAndDropTable(t *testing.T) {
+	// Schema com coluna auto incremento e uma coluna com nome reservado, que precisa de aspas
+	schema := config.Schema{
+		TableName: "orders",
+		Fields: map[string]string{
+			"id":         "INT AUTO_INCREMENT PRIMARY KEY",
+			"order":      "INT NOT NULL",
+			"customer":   "VARCHAR(100) NOT NULL",
+			"created_at": "TIMESTAMP",
+		},
+	}
+
+	for _, name := range []string{"generic", "mysql", "postgresql", "sqlite", "sqlserver", "firebirdsql", "oracle"} {
+		t.Run(name, func(t *testing.T) {
+			d, ok := dialect.ByName(name)
+			assert.True(t, ok, "Dialeto não encontrado")
+			assertGolden(t, name, d.CreateTable(schema)+"\n"+d.DropTable(schema.TableName))
+		})
+	}
+}
+
+func TestTypedColumns(t *testing.T) {
+	// O mesmo schema tipado deve gerar DDL válido em cada banco
+	schema := config.Schema{
+		TableName: "products",
+		Columns: []config.Column{
+			{Name: "id", DataType: config.BigInt, PrimaryKey: true, AutoIncrement: true},
+			{Name: "sku", DataType: config.UUID, Unique: true},
+			{Name: "name", DataType: config.String, Length: 120, Comment: "Nome exibido na loja"},
+			{Name: "description", DataType: config.Text, Nullable: true},
+			{Name: "price", DataType: config.Decimal, Precision: 10, Scale: 2, Default: "0"},
+			{Name: "weight", DataType: config.Float, Nullable: true},
+			{Name: "active", DataType: config.Boolean},
+			{Name: "attributes", DataType: config.JSON, Nullable: true},
+			{Name: "image", DataType: config.Binary, Nullable: true},
+			{Name: "released_on", DataType: config.Date, Nullable: true},
+			{Name: "created_at", DataType: config.Timestamp, Default: "CURRENT_TIMESTAMP"},
+		},
+	}
+
+	for _, name := range []string{"generic", "mysql", "postgresql", "sqlite", "sqlserver", "firebirdsql", "oracle"} {
+		t.Run(name, func(t *testing.T) {
+			d, _ := dialect.ByName(name)
+			assertGolden(t, name+"_typed", d.CreateTable(schema))
+		})
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	// Duas tabelas com restrição UNIQUE, CHECK, índices e uma chave estrangeira entre elas
+	customers := config.Schema{
+		TableName:         "customers",
+		Columns:           []config.Column{{Name: "id", DataType: config.Integer, PrimaryKey: true}, {Name: "email", DataType: config.String}},
+		UniqueConstraints: []config.UniqueConstraint{{Columns: []string{"email"}}},
+	}
+	orders := config.Schema{
+		TableName: "orders",
+		Columns: []config.Column{
+			{Name: "id", DataType: config.Integer, PrimaryKey: true},
+			{Name: "customer_id", DataType: config.Integer},
+			{Name: "total", DataType: config.Decimal, Precision: 10, Scale: 2},
+		},
+		Indexes:     []config.Index{{Columns: []string{"customer_id", "total"}}, {Name: "orders_total", Columns: []string{"total"}, Unique: true}},
+		ForeignKeys: []config.ForeignKey{{Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}, OnDelete: "cascade", OnUpdate: "restrict"}},
+		Checks:      []config.Check{{Expression: "total >= 0"}},
+	}
+
+	for _, name := range []string{"generic", "mysql", "postgresql", "sqlite", "sqlserver", "firebirdsql", "oracle"} {
+		t.Run(name, func(t *testing.T) {
+			d, _ := dialect.ByName(name)
+			up := d.CreateTable(customers) + d.CreateTable(orders) +
+				dialect.CreateConstraints(d, customers) + dialect.CreateConstraints(d, orders) +
+				dialect.CreateForeignKeys(d, customers) + dialect.CreateForeignKeys(d, orders)
+			down := dialect.DropForeignKeys(d, orders) + dialect.DropForeignKeys(d, customers) +
+				dialect.DropConstraints(d, orders) + dialect.DropConstraints(d, customers)
+			assertGolden(t, name+"_constraints", up+"\n"+down)
+		})
+	}
+}
+
+func TestAlterColumns(t *testing.T) {
+	// Coluna que passa a ter 200 caracteres, aceitar NULL e ter valor padrão
+	from := config.Column{Name: "title", DataType: config.String, Length: 100}
+	to := config.Column{Name: "title", DataType: config.String, Length: 200, Nullable: true, Default: "''"}
+	added := config.Column{Name: "published", DataType: config.Boolean, Default: "0"}
+
+	for _, name := range []string{"generic", "mysql", "postgresql", "sqlite", "sqlserver", "firebirdsql", "oracle"} {
+		t.Run(name, func(t *testing.T) {
+			d, _ := dialect.ByName(name)
+			assertGolden(t, name+"_alter", d.AddColumn("posts", added)+d.RenameColumn("posts", "name", "title")+
+				d.AlterColumn("posts", from, to)+d.DropColumn("posts", "published"))
+		})
+	}
+}
+
+func TestIdent(t *testing.T) {
+	assert.Equal(t, "users", dialect.Ident(dialect.MySQL, "users"))
+	assert.Equal(t, "`order`", dialect.Ident(dialect.MySQL, "order"))
+	assert.Equal(t, `"first name"`, dialect.Ident(dialect.PostgreSQL, "first name"))
+	assert.Equal(t, "[a]]b]", dialect.Ident(dialect.SQLServer, "a]b"))
+}
@@ -0,0 +1,78 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// Firebird é o dialeto do Firebird.
+var Firebird Dialect = firebird{}
+
+type firebird struct{ generic }
+
+func (firebird) Name() string           { return "firebirdsql" }
+func (firebird) TransactionalDDL() bool { return true }
+
+func (firebird) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.Text, config.JSON:
+		return "BLOB SUB_TYPE TEXT"
+	case config.Binary:
+		return "BLOB SUB_TYPE BINARY"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition converte AUTO_INCREMENT em coluna identity (Firebird 3 ou superior).
+func (d firebird) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return identityColumn(column.Type)
+	}
+	return typedColumn(d, column, "GENERATED BY DEFAULT AS IDENTITY")
+}
+
+// CreateTable gera CREATE TABLE sem proteção, pois o Firebird não suporta IF NOT EXISTS; a tabela de
+// histórico garante que a migração seja executada uma única vez. Os comentários das colunas são gerados
+// em COMMENT ON COLUMN.
+func (d firebird) CreateTable(schema config.Schema) string {
+	return formatCreateTable("CREATE TABLE "+Ident(d, schema.TableName), columnDefinitions(d, schema)) + commentStatements(d, schema)
+}
+
+// DropTable gera DROP TABLE sem proteção, pois o Firebird não suporta IF EXISTS.
+func (d firebird) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE %s;\n", Ident(d, table))
+}
+
+// referentialActions converte RESTRICT, que o Firebird não suporta, em NO ACTION.
+func (firebird) referentialActions(onDelete string, onUpdate string) (string, string) {
+	return restrictToNoAction(onDelete), restrictToNoAction(onUpdate)
+}
+
+// AddColumn gera ALTER TABLE ... ADD, pois o Firebird não aceita a palavra COLUMN nesse comando.
+func (d firebird) AddColumn(table string, column config.Column) string {
+	return addColumn(d, table, "ADD", column)
+}
+
+// DropColumn gera ALTER TABLE ... DROP, pois o Firebird não aceita a palavra COLUMN nesse comando.
+func (d firebird) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP", name)
+}
+
+// RenameColumn gera ALTER COLUMN ... TO, a forma do Firebird para renomear colunas.
+func (d firebird) RenameColumn(table string, from string, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TO %s;\n", Ident(d, table), Ident(d, from), Ident(d, to))
+}
+
+// AlterColumn gera as cláusulas ALTER COLUMN do padrão SQL; a mudança de nulidade exige o Firebird 3 ou superior.
+func (d firebird) AlterColumn(table string, from config.Column, to config.Column) string {
+	return standardAlterColumn(d, table, from, to)
+}
+
+func (d firebird) CreateIndex(table string, name string, index config.Index) string {
+	return createIndex(d, table, name, index, "")
+}
+
+func (d firebird) DropIndex(table string, name string) string {
+	return fmt.Sprintf("DROP INDEX %s;\n", Ident(d, name))
+}
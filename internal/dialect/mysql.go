@@ -0,0 +1,101 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// MySQL é o dialeto do MySQL. Comandos DDL confirmam implicitamente a transação em andamento.
+var MySQL Dialect = mysql{}
+
+type mysql struct{ generic }
+
+func (mysql) Name() string { return "mysql" }
+
+func (mysql) QuoteIdent(name string) string { return quote(name, "`", "`") }
+
+// TimestampType retorna DATETIME, pois no MySQL a primeira coluna TIMESTAMP NOT NULL pode receber
+// ON UPDATE CURRENT_TIMESTAMP implicitamente (explicit_defaults_for_timestamp=OFF), e TIMESTAMP termina em 2038.
+func (mysql) TimestampType() string { return "DATETIME" }
+
+func (mysql) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.Integer:
+		return "INT"
+	case config.Float:
+		return "DOUBLE"
+	case config.Boolean:
+		// BOOLEAN é um sinônimo de TINYINT(1), o tipo que o MySQL registra no catálogo
+		return "TINYINT(1)"
+	case config.Timestamp:
+		return "DATETIME"
+	case config.Binary:
+		if column.Length > 0 {
+			return SizedType("VARBINARY", column.Length, 0)
+		}
+		return "LONGBLOB"
+	case config.Text:
+		return "LONGTEXT"
+	case config.JSON:
+		return "JSON"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition gera AUTO_INCREMENT nas colunas auto incremento e o comentário na própria coluna.
+func (d mysql) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return column.Type
+	}
+	definition := typedColumn(d, column, "AUTO_INCREMENT")
+	if column.Comment != "" {
+		definition += " COMMENT " + stringLiteral(column.Comment)
+	}
+	return definition
+}
+
+func (d mysql) CreateTable(schema config.Schema) string {
+	return formatCreateTable("CREATE TABLE IF NOT EXISTS "+Ident(d, schema.TableName), columnDefinitions(d, schema))
+}
+
+func (d mysql) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", Ident(d, table))
+}
+
+func (d mysql) AddColumn(table string, column config.Column) string {
+	return addColumn(d, table, "ADD COLUMN", column)
+}
+
+func (d mysql) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP COLUMN", name)
+}
+
+// RenameColumn gera RENAME COLUMN, disponível a partir do MySQL 8.0.
+func (d mysql) RenameColumn(table string, from string, to string) string {
+	return renameColumn(d, table, from, to)
+}
+
+// AlterColumn gera MODIFY COLUMN com a nova definição completa da coluna, que também aceita definições livres.
+func (d mysql) AlterColumn(table string, from config.Column, to config.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;\n", Ident(d, table), columnDefinition(d, to))
+}
+
+// CreateIndex gera CREATE INDEX sem proteção, pois o MySQL não suporta CREATE INDEX IF NOT EXISTS.
+func (d mysql) CreateIndex(table string, name string, index config.Index) string {
+	return createIndex(d, table, name, index, "")
+}
+
+func (d mysql) DropIndex(table string, name string) string {
+	return fmt.Sprintf("DROP INDEX %s ON %s;\n", Ident(d, name), Ident(d, table))
+}
+
+func (d mysql) AddConstraint(table string, constraint Constraint) string {
+	return addConstraint(d, table, constraint)
+}
+
+// DropConstraint usa DROP FOREIGN KEY, DROP INDEX e DROP CHECK, pois DROP CONSTRAINT só existe a partir do MySQL 8.0.19.
+func (d mysql) DropConstraint(table string, constraint Constraint) string {
+	clause := map[ConstraintKind]string{UniqueConstraint: "INDEX", CheckConstraint: "CHECK", ForeignKeyConstraint: "FOREIGN KEY"}[constraint.Kind]
+	return fmt.Sprintf("ALTER TABLE %s DROP %s %s;\n", Ident(d, table), clause, Ident(d, constraint.Name))
+}
@@ -0,0 +1,121 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// Oracle é o dialeto do Oracle. Está disponível para a geração de migrações, mas ainda não há driver
+// registrado para executá-las.
+var Oracle Dialect = oracle{}
+
+type oracle struct{ generic }
+
+func (oracle) Name() string             { return "oracle" }
+func (oracle) Placeholder(n int) string { return numberedPlaceholder(":", n) }
+
+func (oracle) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.SmallInt:
+		return "NUMBER(5)"
+	case config.Integer:
+		return "NUMBER(10)"
+	case config.BigInt:
+		return "NUMBER(19)"
+	case config.Decimal:
+		return SizedType("NUMBER", column.Precision, column.Scale)
+	case config.Float:
+		return "BINARY_DOUBLE"
+	case config.Boolean:
+		return "NUMBER(1)"
+	case config.String:
+		return SizedType("VARCHAR2", stringLength(column), 0)
+	case config.Text, config.JSON:
+		return "CLOB"
+	case config.UUID:
+		return "VARCHAR2(36)"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition converte AUTO_INCREMENT em coluna identity (Oracle 12c ou superior).
+func (d oracle) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return identityColumn(column.Type)
+	}
+	return typedColumn(d, column, "GENERATED BY DEFAULT AS IDENTITY")
+}
+
+// CreateTable gera CREATE TABLE sem proteção, pois o Oracle (antes da versão 23c) não suporta IF NOT EXISTS;
+// a tabela de histórico garante que a migração seja executada uma única vez. Os comentários das colunas são
+// gerados em COMMENT ON COLUMN.
+func (d oracle) CreateTable(schema config.Schema) string {
+	return formatCreateTable("CREATE TABLE "+Ident(d, schema.TableName), columnDefinitions(d, schema)) + commentStatements(d, schema)
+}
+
+// DropTable gera DROP TABLE sem proteção, pois o Oracle (antes da versão 23c) não suporta IF EXISTS.
+func (d oracle) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE %s;\n", Ident(d, table))
+}
+
+// AddColumn gera ALTER TABLE ... ADD (...), a forma do Oracle para acrescentar colunas.
+func (d oracle) AddColumn(table string, column config.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD (%s);\n", Ident(d, table), columnDefinition(d, column))
+}
+
+func (d oracle) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP COLUMN", name)
+}
+
+func (d oracle) RenameColumn(table string, from string, to string) string {
+	return renameColumn(d, table, from, to)
+}
+
+// AlterColumn gera MODIFY (...) apenas com o que mudou, pois o Oracle recusa declarar NOT NULL em uma
+// coluna que já é NOT NULL.
+func (d oracle) AlterColumn(table string, from config.Column, to config.Column) string {
+	if !typedChange(from, to) {
+		return rawAlterReview(table, from, to)
+	}
+	var parts []string
+	if d.ColumnType(from) != d.ColumnType(to) {
+		parts = append(parts, d.ColumnType(to))
+	}
+	if from.Default != to.Default {
+		if to.Default == "" {
+			parts = append(parts, "DEFAULT NULL")
+		} else {
+			parts = append(parts, "DEFAULT "+to.Default)
+		}
+	}
+	if from.Nullable != to.Nullable {
+		if to.Nullable {
+			parts = append(parts, "NULL")
+		} else {
+			parts = append(parts, "NOT NULL")
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("ALTER TABLE %s MODIFY (%s %s);\n", Ident(d, table), Ident(d, to.Name), strings.Join(parts, " "))
+}
+
+func (d oracle) CreateIndex(table string, name string, index config.Index) string {
+	return createIndex(d, table, name, index, "")
+}
+
+func (d oracle) DropIndex(table string, name string) string {
+	return fmt.Sprintf("DROP INDEX %s;\n", Ident(d, name))
+}
+
+// referentialActions mantém apenas ON DELETE CASCADE e ON DELETE SET NULL, as únicas ações suportadas pelo
+// Oracle; nas demais, o Oracle impede a alteração da linha referenciada.
+func (oracle) referentialActions(onDelete string, onUpdate string) (string, string) {
+	if onDelete != "CASCADE" && onDelete != "SET NULL" {
+		onDelete = ""
+	}
+	return onDelete, ""
+}
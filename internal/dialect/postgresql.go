@@ -0,0 +1,84 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// PostgreSQL é o dialeto do PostgreSQL.
+var PostgreSQL Dialect = postgresql{}
+
+type postgresql struct{ generic }
+
+func (postgresql) Name() string             { return "postgresql" }
+func (postgresql) Placeholder(n int) string { return numberedPlaceholder("$", n) }
+func (postgresql) TransactionalDDL() bool   { return true }
+
+func (postgresql) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.Binary:
+		return "BYTEA"
+	case config.UUID:
+		return "UUID"
+	case config.JSON:
+		return "JSONB"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition converte AUTO_INCREMENT em coluna identity.
+func (d postgresql) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return identityColumn(column.Type)
+	}
+	return typedColumn(d, column, "GENERATED BY DEFAULT AS IDENTITY")
+}
+
+// CreateTable gera CREATE TABLE IF NOT EXISTS, seguido dos comentários das colunas em COMMENT ON COLUMN.
+func (d postgresql) CreateTable(schema config.Schema) string {
+	create := formatCreateTable("CREATE TABLE IF NOT EXISTS "+Ident(d, schema.TableName), columnDefinitions(d, schema))
+	return create + commentStatements(d, schema)
+}
+
+func (d postgresql) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", Ident(d, table))
+}
+
+func (d postgresql) AddColumn(table string, column config.Column) string {
+	return addColumn(d, table, "ADD COLUMN", column)
+}
+
+func (d postgresql) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP COLUMN", name)
+}
+
+func (d postgresql) RenameColumn(table string, from string, to string) string {
+	return renameColumn(d, table, from, to)
+}
+
+func (d postgresql) AlterColumn(table string, from config.Column, to config.Column) string {
+	return standardAlterColumn(d, table, from, to)
+}
+
+func (d postgresql) CreateIndex(table string, name string, index config.Index) string {
+	return createIndex(d, table, name, index, "IF NOT EXISTS ")
+}
+
+func (d postgresql) DropIndex(table string, name string) string {
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;\n", Ident(d, name))
+}
+
+func (d postgresql) AddConstraint(table string, constraint Constraint) string {
+	return addConstraint(d, table, constraint)
+}
+
+func (d postgresql) DropConstraint(table string, constraint Constraint) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;\n", Ident(d, table), Ident(d, constraint.Name))
+}
+
+// identityColumn converte AUTO_INCREMENT na coluna identity do padrão SQL, aceita pelo PostgreSQL,
+// Firebird e Oracle.
+func identityColumn(fieldType string) string {
+	return replaceAutoIncrement(fieldType, "GENERATED BY DEFAULT AS IDENTITY")
+}
@@ -0,0 +1,100 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// SQLite é o dialeto do SQLite.
+var SQLite Dialect = sqlite{}
+
+type sqlite struct{ generic }
+
+func (sqlite) Name() string           { return "sqlite" }
+func (sqlite) TransactionalDDL() bool { return true }
+
+// ColumnType usa os tipos com as afinidades do SQLite: INTEGER, REAL, NUMERIC, TEXT e BLOB.
+func (sqlite) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.SmallInt, config.Integer, config.BigInt, config.Boolean:
+		return "INTEGER"
+	case config.Decimal:
+		return "NUMERIC"
+	case config.Float:
+		return "REAL"
+	case config.String, config.Text, config.UUID, config.JSON:
+		return "TEXT"
+	case config.Timestamp:
+		return "DATETIME"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition gera as colunas auto incremento como INTEGER PRIMARY KEY AUTOINCREMENT, a única forma aceita
+// pelo SQLite. Comentários de coluna não são gerados.
+func (d sqlite) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return sqliteColumnType(column.Type)
+	}
+	if column.AutoIncrement {
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+	return typedColumn(d, column, "")
+}
+
+// CreateTable inclui as restrições UNIQUE e CHECK e as chaves estrangeiras na própria criação da tabela, pois o
+// SQLite não suporta ALTER TABLE ... ADD CONSTRAINT. As referências a tabelas ainda não criadas são aceitas,
+// já que o SQLite só as verifica ao alterar os dados.
+func (d sqlite) CreateTable(schema config.Schema) string {
+	definitions := columnDefinitions(d, schema)
+	for _, constraint := range append(Constraints(d, schema), ForeignKeys(d, schema)...) {
+		definitions = append(definitions, fmt.Sprintf("CONSTRAINT %s %s", Ident(d, constraint.Name), constraint.Definition))
+	}
+	return formatCreateTable("CREATE TABLE IF NOT EXISTS "+Ident(d, schema.TableName), definitions)
+}
+
+func (d sqlite) DropTable(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", Ident(d, table))
+}
+
+func (d sqlite) AddColumn(table string, column config.Column) string {
+	return addColumn(d, table, "ADD COLUMN", column)
+}
+
+// DropColumn gera DROP COLUMN, disponível a partir do SQLite 3.35.
+func (d sqlite) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP COLUMN", name)
+}
+
+// RenameColumn gera RENAME COLUMN, disponível a partir do SQLite 3.25.
+func (d sqlite) RenameColumn(table string, from string, to string) string {
+	return renameColumn(d, table, from, to)
+}
+
+// AlterColumn retorna um comentário de revisão, pois o SQLite não suporta ALTER COLUMN: a alteração exige
+// recriar a tabela e copiar os dados.
+func (d sqlite) AlterColumn(table string, from config.Column, to config.Column) string {
+	return ReviewComment("o SQLite não altera colunas; recrie a tabela %s para mudar a coluna %s de %q para %q",
+		table, to.Name, definitionOf(from), definitionOf(to))
+}
+
+// AddConstraint retorna "", pois as restrições são criadas por CreateTable.
+func (sqlite) AddConstraint(table string, constraint Constraint) string { return "" }
+
+// DropConstraint retorna "", pois o SQLite não permite remover restrições; elas são removidas com a tabela.
+func (sqlite) DropConstraint(table string, constraint Constraint) string { return "" }
+
+// sqliteColumnType adapta uma definição de coluna escrita para o MySQL ao SQLite. No SQLite, uma coluna
+// auto incremento precisa ser declarada exatamente como INTEGER PRIMARY KEY AUTOINCREMENT, e a palavra-chave
+// AUTO_INCREMENT não existe.
+func sqliteColumnType(fieldType string) string {
+	if !autoIncrementPattern.MatchString(fieldType) {
+		return fieldType
+	}
+	if strings.Contains(strings.ToUpper(fieldType), "PRIMARY KEY") {
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+	return strings.TrimSpace(autoIncrementPattern.ReplaceAllString(fieldType, ""))
+}
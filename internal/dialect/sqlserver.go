@@ -0,0 +1,145 @@
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+// SQLServer é o dialeto do Microsoft SQL Server.
+var SQLServer Dialect = sqlserver{}
+
+type sqlserver struct{ generic }
+
+func (sqlserver) Name() string             { return "sqlserver" }
+func (sqlserver) Placeholder(n int) string { return numberedPlaceholder("@p", n) }
+func (sqlserver) TransactionalDDL() bool   { return true }
+
+// TimestampType retorna DATETIME2, pois no SQL Server TIMESTAMP é um sinônimo de ROWVERSION e não guarda data e hora.
+func (sqlserver) TimestampType() string { return "DATETIME2" }
+
+// BatchSeparator retorna GO, que não é um comando T-SQL: os scripts são divididos em lotes pelo cliente.
+func (sqlserver) BatchSeparator() string { return "GO" }
+
+func (sqlserver) QuoteIdent(name string) string { return quote(name, "[", "]") }
+
+func (sqlserver) ColumnType(column config.Column) string {
+	switch column.DataType {
+	case config.Integer:
+		return "INT"
+	case config.Float:
+		return "FLOAT"
+	case config.Boolean:
+		return "BIT"
+	case config.String:
+		return SizedType("NVARCHAR", stringLength(column), 0)
+	case config.Text, config.JSON:
+		return "NVARCHAR(MAX)"
+	case config.Timestamp:
+		return "DATETIME2"
+	case config.Binary:
+		if column.Length > 0 {
+			return SizedType("VARBINARY", column.Length, 0)
+		}
+		return "VARBINARY(MAX)"
+	case config.UUID:
+		return "UNIQUEIDENTIFIER"
+	}
+	return standardType(column)
+}
+
+// ColumnDefinition converte AUTO_INCREMENT em IDENTITY(1,1).
+func (d sqlserver) ColumnDefinition(column config.Column) string {
+	if column.Type != "" {
+		return replaceAutoIncrement(column.Type, "IDENTITY(1,1)")
+	}
+	return typedColumn(d, column, "IDENTITY(1,1)")
+}
+
+// CreateTable protege a criação com IF OBJECT_ID(...) IS NULL, pois versões antigas do SQL Server não
+// suportam CREATE TABLE IF NOT EXISTS. Os comentários das colunas são gravados como a propriedade
+// MS_Description, no mesmo bloco protegido da criação.
+func (d sqlserver) CreateTable(schema config.Schema) string {
+	table := Ident(d, schema.TableName)
+	create := formatCreateTable("CREATE TABLE "+table, columnDefinitions(d, schema))
+	guard := fmt.Sprintf("IF OBJECT_ID(N%s, N'U') IS NULL\n", stringLiteral(table))
+
+	comments := ""
+	for _, column := range schema.OrderedColumns() {
+		if column.Type == "" && column.Comment != "" {
+			comments += fmt.Sprintf("EXEC sp_addextendedproperty N'MS_Description', N%s, N'SCHEMA', N'dbo', N'TABLE', N%s, N'COLUMN', N%s;\n",
+				stringLiteral(column.Comment), stringLiteral(schema.TableName), stringLiteral(column.Name))
+		}
+	}
+	if comments == "" {
+		return guard + create + "GO\n"
+	}
+	return guard + "BEGIN\n" + create + comments + "END;\nGO\n"
+}
+
+func (d sqlserver) DropTable(table string) string {
+	table = Ident(d, table)
+	return fmt.Sprintf("IF OBJECT_ID(N%s, N'U') IS NOT NULL\n    DROP TABLE %s;\nGO\n", stringLiteral(table), table)
+}
+
+// AddColumn gera ALTER TABLE ... ADD, pois o SQL Server não aceita a palavra COLUMN nesse comando.
+func (d sqlserver) AddColumn(table string, column config.Column) string {
+	return addColumn(d, table, "ADD", column) + "GO\n"
+}
+
+func (d sqlserver) DropColumn(table string, name string) string {
+	return dropColumn(d, table, "DROP COLUMN", name) + "GO\n"
+}
+
+// RenameColumn usa a procedure sp_rename, pois o SQL Server não suporta RENAME COLUMN.
+func (d sqlserver) RenameColumn(table string, from string, to string) string {
+	return fmt.Sprintf("EXEC sp_rename N%s, N%s, N'COLUMN';\nGO\n", stringLiteral(Ident(d, table)+"."+Ident(d, from)), stringLiteral(to))
+}
+
+// AlterColumn gera ALTER COLUMN com o tipo e a nulidade da coluna. O valor padrão é uma restrição com nome
+// gerado pelo banco, por isso a sua alteração é marcada para revisão.
+func (d sqlserver) AlterColumn(table string, from config.Column, to config.Column) string {
+	if !typedChange(from, to) {
+		return rawAlterReview(table, from, to)
+	}
+	statements := ""
+	if d.ColumnType(from) != d.ColumnType(to) || from.Nullable != to.Nullable {
+		nullability := "NOT NULL"
+		if to.Nullable {
+			nullability = "NULL"
+		}
+		statements += fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s;\nGO\n", Ident(d, table), Ident(d, to.Name), d.ColumnType(to), nullability)
+	}
+	if from.Default != to.Default {
+		statements += ReviewComment("altere a restrição DEFAULT da coluna %s.%s de %q para %q", table, to.Name, from.Default, to.Default)
+	}
+	return statements
+}
+
+// CreateIndex protege a criação consultando sys.indexes, pois o SQL Server não suporta CREATE INDEX IF NOT EXISTS.
+func (d sqlserver) CreateIndex(table string, name string, index config.Index) string {
+	return fmt.Sprintf("IF NOT EXISTS (%s)\n%sGO\n", indexExists(d, table, name), createIndex(d, table, name, index, ""))
+}
+
+func (d sqlserver) DropIndex(table string, name string) string {
+	return fmt.Sprintf("IF EXISTS (%s)\n    DROP INDEX %s ON %s;\nGO\n", indexExists(d, table, name), Ident(d, name), Ident(d, table))
+}
+
+// AddConstraint protege a criação com IF OBJECT_ID(...) IS NULL, pois as restrições são objetos do schema.
+func (d sqlserver) AddConstraint(table string, constraint Constraint) string {
+	return fmt.Sprintf("IF OBJECT_ID(N%s) IS NULL\n%sGO\n", stringLiteral(Ident(d, constraint.Name)), addConstraint(d, table, constraint))
+}
+
+func (d sqlserver) DropConstraint(table string, constraint Constraint) string {
+	return fmt.Sprintf("IF OBJECT_ID(N%s) IS NOT NULL\n    %sGO\n", stringLiteral(Ident(d, constraint.Name)), dropConstraint(d, table, constraint))
+}
+
+// referentialActions converte RESTRICT, que o SQL Server não suporta, em NO ACTION.
+func (sqlserver) referentialActions(onDelete string, onUpdate string) (string, string) {
+	return restrictToNoAction(onDelete), restrictToNoAction(onUpdate)
+}
+
+// indexExists retorna a consulta que verifica se o índice existe na tabela.
+func indexExists(d Dialect, table string, name string) string {
+	return fmt.Sprintf("SELECT 1 FROM sys.indexes WHERE name = N%s AND object_id = OBJECT_ID(N%s)", stringLiteral(name), stringLiteral(Ident(d, table)))
+}
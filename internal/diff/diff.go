@@ -0,0 +1,396 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// Kind identifica o tipo de uma mudança entre duas versões dos schemas.
+type Kind string
+
+const (
+	CreateTable    Kind = "create-table"
+	DropTable      Kind = "drop-table"
+	AddColumn      Kind = "add-column"
+	DropColumn     Kind = "drop-column"
+	RenameColumn   Kind = "rename-column"
+	AlterColumn    Kind = "alter-column"
+	AddIndex       Kind = "add-index"
+	DropIndex      Kind = "drop-index"
+	AddConstraint  Kind = "add-constraint"
+	DropConstraint Kind = "drop-constraint"
+	AddForeignKey  Kind = "add-foreign-key"
+	DropForeignKey Kind = "drop-foreign-key"
+)
+
+// Change é uma mudança entre duas versões dos schemas.
+type Change struct {
+	Kind     Kind
+	Table    string
+	Schema   config.Schema // Versão do schema que contém o objeto: a desejada nas criações e a anterior nas remoções
+	Column   config.Column // Coluna acrescentada, ou a nova versão da coluna alterada ou renomeada
+	Previous config.Column // Coluna removida, ou a versão anterior da coluna alterada ou renomeada
+	Name     string        // Nome do índice ou da restrição
+	Index    config.Index
+}
+
+// String descreve a mudança, por exemplo "acrescentar coluna users.email".
+func (c Change) String() string {
+	switch c.Kind {
+	case CreateTable:
+		return "criar tabela " + c.Table
+	case DropTable:
+		return "remover tabela " + c.Table
+	case AddColumn:
+		return fmt.Sprintf("acrescentar coluna %s.%s", c.Table, c.Column.Name)
+	case DropColumn:
+		return fmt.Sprintf("remover coluna %s.%s", c.Table, c.Previous.Name)
+	case RenameColumn:
+		return fmt.Sprintf("renomear coluna %s.%s para %s", c.Table, c.Previous.Name, c.Column.Name)
+	case AlterColumn:
+		return fmt.Sprintf("alterar coluna %s.%s", c.Table, c.Column.Name)
+	case AddIndex:
+		return fmt.Sprintf("criar índice %s em %s", c.Name, c.Table)
+	case DropIndex:
+		return fmt.Sprintf("remover índice %s de %s", c.Name, c.Table)
+	case AddConstraint, AddForeignKey:
+		return fmt.Sprintf("criar restrição %s em %s", c.Name, c.Table)
+	case DropConstraint, DropForeignKey:
+		return fmt.Sprintf("remover restrição %s de %s", c.Name, c.Table)
+	}
+	return string(c.Kind)
+}
+
+// Diff é o resultado da comparação de duas versões dos schemas.
+type Diff struct {
+	Up       []Change // Mudanças que levam da versão anterior à desejada, em ordem de execução
+	Down     []Change // Mudanças que desfazem Up, em ordem de execução
+	Warnings []string // Pontos que precisam de revisão manual, como possíveis renomeações de colunas
+}
+
+// Empty indica se as duas versões dos schemas são iguais.
+func (d Diff) Empty() bool {
+	return len(d.Up) == 0
+}
+
+// Compare compara a versão anterior dos schemas com a desejada e retorna as mudanças entre elas.
+// As tabelas são associadas pelo nome e as colunas pelo nome ou, quando renomeadas, por Column.RenamedFrom.
+// Renomeações não são deduzidas: uma coluna removida e outra acrescentada com a mesma definição geram um aviso
+// em Warnings, para que a renomeação seja confirmada com RenamedFrom.
+// As mudanças seguem a ordem de dependência: remoção de chaves estrangeiras, de índices e restrições e de
+// tabelas; criação de tabelas; mudanças de colunas; criação de índices e restrições e de chaves estrangeiras.
+func Compare(previous []config.Schema, desired []config.Schema) Diff {
+	// Renomeações declaradas na versão desejada e o seu inverso, usado na reversão
+	renames := make(map[string]map[string]string)
+	reverse := make(map[string]map[string]string)
+	for _, schema := range desired {
+		for _, column := range schema.OrderedColumns() {
+			if column.RenamedFrom != "" && column.RenamedFrom != column.Name {
+				if renames[schema.TableName] == nil {
+					renames[schema.TableName] = make(map[string]string)
+					reverse[schema.TableName] = make(map[string]string)
+				}
+				renames[schema.TableName][column.Name] = column.RenamedFrom
+				reverse[schema.TableName][column.RenamedFrom] = column.Name
+			}
+		}
+	}
+
+	up, warnings := compare(previous, desired, renames)
+	down, _ := compare(desired, previous, reverse)
+	return Diff{Up: up, Down: down, Warnings: warnings}
+}
+
+// phases agrupa as mudanças pelas etapas da ordem de dependência.
+type phases struct {
+	dropForeignKeys, dropConstraints, dropTables, createTables, columns, addConstraints, addForeignKeys []Change
+}
+
+// compare retorna as mudanças de previous para desired, com as renomeações de colunas informadas em
+// renames (tabela → nome novo → nome anterior), e os avisos da comparação.
+func compare(previous []config.Schema, desired []config.Schema, renames map[string]map[string]string) ([]Change, []string) {
+	var p phases
+	var warnings []string
+
+	previousByName := make(map[string]config.Schema, len(previous))
+	for _, schema := range previous {
+		previousByName[schema.TableName] = schema
+	}
+	desiredByName := make(map[string]bool, len(desired))
+	for _, schema := range desired {
+		desiredByName[schema.TableName] = true
+	}
+
+	for _, schema := range previous {
+		if !desiredByName[schema.TableName] {
+			p.dropTables = append(p.dropTables, Change{Kind: DropTable, Table: schema.TableName, Schema: schema})
+		}
+	}
+
+	for _, schema := range desired {
+		old, exists := previousByName[schema.TableName]
+		if !exists {
+			// Tabela nova: criada com todas as suas restrições
+			p.createTables = append(p.createTables, Change{Kind: CreateTable, Table: schema.TableName, Schema: schema})
+			compareKeys(&p, config.Schema{TableName: schema.TableName}, schema)
+			continue
+		}
+		warnings = append(warnings, compareColumns(&p, old, schema, renames[schema.TableName])...)
+		compareKeys(&p, old, schema)
+	}
+
+	var changes []Change
+	for _, phase := range [][]Change{p.dropForeignKeys, p.dropConstraints, p.dropTables, p.createTables, p.columns, p.addConstraints, p.addForeignKeys} {
+		changes = append(changes, phase...)
+	}
+	return changes, warnings
+}
+
+// compareColumns acrescenta as mudanças de colunas entre as duas versões da tabela e retorna os avisos.
+func compareColumns(p *phases, old config.Schema, schema config.Schema, renames map[string]string) []string {
+	table := schema.TableName
+	var warnings []string
+
+	oldColumns := make(map[string]config.Column)
+	for _, column := range old.OrderedColumns() {
+		oldColumns[column.Name] = column
+	}
+	newColumns := schema.OrderedColumns()
+	consumed := make(map[string]bool)
+
+	var renamed, altered, added, dropped []Change
+	for _, column := range newColumns {
+		name := column.Name
+		if from, ok := renames[column.Name]; ok {
+			if _, exists := oldColumns[from]; exists && !consumed[from] {
+				name = from
+				renamed = append(renamed, Change{Kind: RenameColumn, Table: table, Schema: schema, Column: column, Previous: oldColumns[from]})
+			} else {
+				warnings = append(warnings, fmt.Sprintf("Tabela %s: a coluna %s foi renomeada de %s, mas a coluna %s não existe na versão anterior", table, column.Name, from, from))
+			}
+		}
+
+		previous, exists := oldColumns[name]
+		if !exists || consumed[name] {
+			added = append(added, Change{Kind: AddColumn, Table: table, Schema: schema, Column: column})
+			continue
+		}
+		consumed[name] = true
+
+		// Compara a coluna com a versão anterior já com o novo nome
+		previous.Name = column.Name
+		if columnChanged(previous, column) {
+			altered = append(altered, Change{Kind: AlterColumn, Table: table, Schema: schema, Column: column, Previous: previous})
+		}
+		warnings = append(warnings, flagChanges(table, previous, column)...)
+	}
+	for _, column := range old.OrderedColumns() {
+		if !consumed[column.Name] {
+			dropped = append(dropped, Change{Kind: DropColumn, Table: table, Schema: old, Previous: column})
+		}
+	}
+
+	// Uma coluna removida e outra acrescentada com a mesma definição podem ser uma renomeação
+	for _, drop := range dropped {
+		for _, add := range added {
+			if sameDefinition(drop.Previous, add.Column) {
+				warnings = append(warnings, fmt.Sprintf("Tabela %s: a coluna %s foi removida e a coluna %s acrescentada com a mesma definição; "+
+					"se for uma renomeação, informe RenamedFrom: %q na coluna %s", table, drop.Previous.Name, add.Column.Name, drop.Previous.Name, add.Column.Name))
+			}
+		}
+	}
+
+	for _, changes := range [][]Change{renamed, altered, added, dropped} {
+		p.columns = append(p.columns, changes...)
+	}
+	return warnings
+}
+
+// compareKeys acrescenta as mudanças de índices, restrições UNIQUE e CHECK e chaves estrangeiras entre as duas
+// versões da tabela. Um objeto com o mesmo nome e definição diferente é removido e criado novamente.
+func compareKeys(p *phases, old config.Schema, schema config.Schema) {
+	table := schema.TableName
+
+	oldIndexes, newIndexes := indexesByName(old), indexesByName(schema)
+	for _, index := range old.Indexes {
+		name := old.IndexName(index)
+		if current, ok := newIndexes[name]; !ok || !reflect.DeepEqual(current, oldIndexes[name]) {
+			p.dropConstraints = append(p.dropConstraints, Change{Kind: DropIndex, Table: table, Schema: old, Name: name, Index: index})
+		}
+	}
+	oldKeys, newKeys := keysByName(old), keysByName(schema)
+	for _, name := range keyNames(old) {
+		if current, ok := newKeys[name]; !ok || !reflect.DeepEqual(current, oldKeys[name]) {
+			change := Change{Kind: DropConstraint, Table: table, Schema: old, Name: name}
+			if _, isForeignKey := oldKeys[name].(config.ForeignKey); isForeignKey {
+				change.Kind = DropForeignKey
+				p.dropForeignKeys = append(p.dropForeignKeys, change)
+			} else {
+				p.dropConstraints = append(p.dropConstraints, change)
+			}
+		}
+	}
+
+	for _, name := range keyNames(schema) {
+		if previous, ok := oldKeys[name]; !ok || !reflect.DeepEqual(previous, newKeys[name]) {
+			change := Change{Kind: AddConstraint, Table: table, Schema: schema, Name: name}
+			if _, isForeignKey := newKeys[name].(config.ForeignKey); isForeignKey {
+				change.Kind = AddForeignKey
+				p.addForeignKeys = append(p.addForeignKeys, change)
+			} else {
+				p.addConstraints = append(p.addConstraints, change)
+			}
+		}
+	}
+	for _, index := range schema.Indexes {
+		name := schema.IndexName(index)
+		if previous, ok := oldIndexes[name]; !ok || !reflect.DeepEqual(previous, newIndexes[name]) {
+			p.addConstraints = append(p.addConstraints, Change{Kind: AddIndex, Table: table, Schema: schema, Name: name, Index: index})
+		}
+	}
+}
+
+// indexesByName retorna os índices do schema pelo nome, já com o nome padrão aplicado.
+func indexesByName(schema config.Schema) map[string]config.Index {
+	indexes := make(map[string]config.Index, len(schema.Indexes))
+	for _, index := range schema.Indexes {
+		index.Name = schema.IndexName(index)
+		indexes[index.Name] = index
+	}
+	return indexes
+}
+
+// keysByName retorna as restrições UNIQUE e CHECK e as chaves estrangeiras do schema pelo nome, já com o
+// nome padrão aplicado.
+func keysByName(schema config.Schema) map[string]interface{} {
+	keys := make(map[string]interface{})
+	for _, unique := range schema.UniqueConstraints {
+		unique.Name = schema.UniqueName(unique)
+		keys[unique.Name] = unique
+	}
+	for i, check := range schema.Checks {
+		check.Name = schema.CheckName(i)
+		keys[check.Name] = check
+	}
+	for _, fk := range schema.ForeignKeys {
+		fk.Name = schema.ForeignKeyName(fk)
+		keys[fk.Name] = fk
+	}
+	return keys
+}
+
+// keyNames retorna os nomes das restrições UNIQUE e CHECK e das chaves estrangeiras, na ordem do schema.
+func keyNames(schema config.Schema) []string {
+	var names []string
+	for _, unique := range schema.UniqueConstraints {
+		names = append(names, schema.UniqueName(unique))
+	}
+	for i := range schema.Checks {
+		names = append(names, schema.CheckName(i))
+	}
+	for _, fk := range schema.ForeignKeys {
+		names = append(names, schema.ForeignKeyName(fk))
+	}
+	return names
+}
+
+// columnChanged indica se o tipo, a nulidade ou o valor padrão da coluna mudaram. Definições livres são
+// comparadas sem diferenciar maiúsculas de minúsculas e espaços repetidos.
+func columnChanged(from config.Column, to config.Column) bool {
+	if from.Type != "" || to.Type != "" {
+		return Normalize(from.Type) != Normalize(to.Type)
+	}
+	return from.DataType != to.DataType || from.Length != to.Length || from.Precision != to.Precision ||
+		from.Scale != to.Scale || from.Nullable != to.Nullable || from.Default != to.Default
+}
+
+// flagChanges retorna os avisos das mudanças de coluna que não são geradas automaticamente: chave primária,
+// auto incremento e UNIQUE declarados na coluna.
+func flagChanges(table string, from config.Column, to config.Column) []string {
+	if from.Type != "" || to.Type != "" {
+		return nil
+	}
+	var warnings []string
+	flag := func(attribute string, before bool, after bool) {
+		if before != after {
+			warnings = append(warnings, fmt.Sprintf("Tabela %s: a mudança de %s na coluna %s não é gerada automaticamente", table, attribute, to.Name))
+		}
+	}
+	flag("PrimaryKey", from.PrimaryKey, to.PrimaryKey)
+	flag("AutoIncrement", from.AutoIncrement, to.AutoIncrement)
+	flag("Unique", from.Unique, to.Unique)
+	return warnings
+}
+
+// sameDefinition indica se as duas colunas têm a mesma definição, desconsiderando o nome.
+func sameDefinition(a config.Column, b config.Column) bool {
+	a.Name, a.RenamedFrom, a.Type = "", "", Normalize(a.Type)
+	b.Name, b.RenamedFrom, b.Type = "", "", Normalize(b.Type)
+	return reflect.DeepEqual(a, b)
+}
+
+// Normalize converte a definição para maiúsculas e reduz os espaços, para a comparação. Também é usada na
+// detecção de divergências (veja o pacote drift), para que as duas comparações tratem as definições igualmente.
+func Normalize(definition string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(definition)), " ")
+}
+
+// SQL retorna os comandos das mudanças no dialeto informado. As mudanças que o banco não permite gerar com
+// segurança são escritas como comentários de revisão (veja dialect.ReviewComment).
+func SQL(d dialect.Dialect, changes []Change) string {
+	created := make(map[string]bool)
+	for _, change := range changes {
+		if change.Kind == CreateTable {
+			created[change.Table] = true
+		}
+	}
+
+	statements := ""
+	for _, change := range changes {
+		switch change.Kind {
+		case CreateTable:
+			statements += d.CreateTable(change.Schema) + "\n"
+		case DropTable:
+			statements += d.DropTable(change.Table)
+		case AddColumn:
+			statements += d.AddColumn(change.Table, change.Column)
+		case DropColumn:
+			statements += d.DropColumn(change.Table, change.Previous.Name)
+		case RenameColumn:
+			statements += d.RenameColumn(change.Table, change.Previous.Name, change.Column.Name)
+		case AlterColumn:
+			statements += d.AlterColumn(change.Table, change.Previous, change.Column)
+		case AddIndex:
+			statements += d.CreateIndex(change.Table, change.Name, change.Index)
+		case DropIndex:
+			statements += d.DropIndex(change.Table, change.Name)
+		case AddConstraint, AddForeignKey:
+			sql := d.AddConstraint(change.Table, constraintOf(d, change))
+			if sql == "" && !created[change.Table] {
+				sql = dialect.ReviewComment("o banco não permite acrescentar a restrição %s à tabela existente %s", change.Name, change.Table)
+			}
+			statements += sql
+		case DropConstraint, DropForeignKey:
+			sql := d.DropConstraint(change.Table, constraintOf(d, change))
+			if sql == "" {
+				sql = dialect.ReviewComment("o banco não permite remover a restrição %s da tabela %s", change.Name, change.Table)
+			}
+			statements += sql
+		}
+	}
+	return statements
+}
+
+// constraintOf retorna a restrição da mudança, escrita no dialeto.
+func constraintOf(d dialect.Dialect, change Change) dialect.Constraint {
+	for _, constraint := range append(dialect.Constraints(d, change.Schema), dialect.ForeignKeys(d, change.Schema)...) {
+		if constraint.Name == change.Name {
+			return constraint
+		}
+	}
+	return dialect.Constraint{Name: change.Name}
+}
@@ -0,0 +1,112 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+// users retorna a versão inicial da tabela users usada nos testes.
+func users() config.Schema {
+	return config.Schema{
+		TableName: "users",
+		Columns: []config.Column{
+			{Name: "id", DataType: config.Integer, PrimaryKey: true},
+			{Name: "name", DataType: config.String, Length: 50},
+			{Name: "nickname", DataType: config.String, Length: 30, Nullable: true},
+		},
+		Indexes: []config.Index{{Columns: []string{"name"}}},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	previous := users()
+
+	// Renomeia name, altera o tamanho, acrescenta email, remove nickname e troca o índice
+	desired := users()
+	desired.Columns = []config.Column{
+		{Name: "id", DataType: config.Integer, PrimaryKey: true},
+		{Name: "full_name", RenamedFrom: "name", DataType: config.String, Length: 100},
+		{Name: "email", DataType: config.String, Length: 100, Nullable: true},
+	}
+	desired.Indexes = []config.Index{{Columns: []string{"email"}}}
+
+	changes := diff.Compare([]config.Schema{previous}, []config.Schema{desired})
+	assert.Empty(t, changes.Warnings)
+	assert.Equal(t, ""+
+		"DROP INDEX IF EXISTS idx_users_name;\n"+
+		"ALTER TABLE users RENAME COLUMN name TO full_name;\n"+
+		"ALTER TABLE users ALTER COLUMN full_name TYPE VARCHAR(100);\n"+
+		"ALTER TABLE users ADD COLUMN email VARCHAR(100);\n"+
+		"ALTER TABLE users DROP COLUMN nickname;\n"+
+		"CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);\n",
+		diff.SQL(dialect.PostgreSQL, changes.Up))
+
+	// A reversão desfaz as mudanças, incluindo a renomeação
+	assert.Equal(t, ""+
+		"DROP INDEX IF EXISTS idx_users_email;\n"+
+		"ALTER TABLE users RENAME COLUMN full_name TO name;\n"+
+		"ALTER TABLE users ALTER COLUMN name TYPE VARCHAR(50);\n"+
+		"ALTER TABLE users ADD COLUMN nickname VARCHAR(30);\n"+
+		"ALTER TABLE users DROP COLUMN email;\n"+
+		"CREATE INDEX IF NOT EXISTS idx_users_name ON users (name);\n",
+		diff.SQL(dialect.PostgreSQL, changes.Down))
+}
+
+func TestCompareFlagsAmbiguousRename(t *testing.T) {
+	previous := users()
+
+	// nickname removida e alias acrescentada com a mesma definição: não deve ser tratada como renomeação
+	desired := users()
+	desired.Columns = []config.Column{
+		{Name: "id", DataType: config.Integer, PrimaryKey: true},
+		{Name: "name", DataType: config.String, Length: 50},
+		{Name: "alias", DataType: config.String, Length: 30, Nullable: true},
+	}
+
+	changes := diff.Compare([]config.Schema{previous}, []config.Schema{desired})
+	assert.Equal(t, []string{`Tabela users: a coluna nickname foi removida e a coluna alias acrescentada com a mesma definição; ` +
+		`se for uma renomeação, informe RenamedFrom: "nickname" na coluna alias`}, changes.Warnings)
+	assert.Equal(t, []diff.Kind{diff.AddColumn, diff.DropColumn}, kinds(changes.Up))
+}
+
+func TestCompareTablesAndForeignKeys(t *testing.T) {
+	previous := []config.Schema{users()}
+
+	// Nova tabela com chave estrangeira para users
+	posts := config.Schema{
+		TableName:   "posts",
+		Columns:     []config.Column{{Name: "id", DataType: config.Integer, PrimaryKey: true}, {Name: "user_id", DataType: config.Integer}},
+		ForeignKeys: []config.ForeignKey{{Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}}},
+	}
+	changes := diff.Compare(previous, []config.Schema{users(), posts})
+	assert.Equal(t, []diff.Kind{diff.CreateTable, diff.AddForeignKey}, kinds(changes.Up))
+	// Na reversão, a chave estrangeira é removida junto com a tabela
+	assert.Equal(t, []diff.Kind{diff.DropTable}, kinds(changes.Down))
+
+	// Esquemas iguais não geram mudanças
+	assert.True(t, diff.Compare(previous, []config.Schema{users()}).Empty())
+}
+
+func TestSQLMarksUnsupportedChangesForReview(t *testing.T) {
+	previous := users()
+	desired := users()
+	desired.Columns[1].Length = 80
+
+	// O SQLite não altera colunas: a mudança é escrita como comentário de revisão
+	changes := diff.Compare([]config.Schema{previous}, []config.Schema{desired})
+	assert.Equal(t, "-- Revisar: o SQLite não altera colunas; recrie a tabela users para mudar a coluna name de \"VARCHAR(50) NOT NULL\" para \"VARCHAR(80) NOT NULL\"\n",
+		diff.SQL(dialect.SQLite, changes.Up))
+	assert.Equal(t, "ALTER TABLE users MODIFY COLUMN name VARCHAR(80) NOT NULL;\n", diff.SQL(dialect.MySQL, changes.Up))
+}
+
+func kinds(changes []diff.Change) []diff.Kind {
+	result := make([]diff.Kind, len(changes))
+	for i, change := range changes {
+		result[i] = change.Kind
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package drift
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/diff"
+)
+
+// Kind identifica o tipo de uma divergência entre a estrutura esperada e a do banco de dados.
+type Kind string
+
+const (
+	MissingTable      Kind = "missing-table"      // Tabela esperada que não existe no banco
+	ExtraTable        Kind = "extra-table"        // Tabela do banco que as migrações não criam
+	MissingColumn     Kind = "missing-column"     // Coluna esperada que não existe no banco
+	ExtraColumn       Kind = "extra-column"       // Coluna do banco que as migrações não criam
+	ChangedColumn     Kind = "changed-column"     // Coluna com tipo, nulidade, valor padrão ou chave diferentes
+	MissingIndex      Kind = "missing-index"      // Índice esperado que não existe no banco
+	ExtraIndex        Kind = "extra-index"        // Índice do banco que as migrações não criam
+	ChangedIndex      Kind = "changed-index"      // Índice com colunas ou unicidade diferentes
+	MissingConstraint Kind = "missing-constraint" // Restrição UNIQUE, CHECK ou chave estrangeira esperada que não existe no banco
+	ExtraConstraint   Kind = "extra-constraint"   // Restrição do banco que as migrações não criam
+	ChangedConstraint Kind = "changed-constraint" // Restrição com definição diferente
+)
+
+// Difference é uma divergência entre a estrutura esperada, resultante das migrações, e a estrutura do banco.
+type Difference struct {
+	Kind     Kind   `json:"kind"`
+	Table    string `json:"table"`
+	Name     string `json:"name,omitempty"`     // Nome da coluna, do índice ou da restrição
+	Expected string `json:"expected,omitempty"` // Definição esperada, no dialeto do banco
+	Actual   string `json:"actual,omitempty"`   // Definição encontrada no banco
+}
+
+// String descreve a divergência, por exemplo "coluna users.email não existe no banco de dados".
+func (d Difference) String() string {
+	object := d.Table
+	if d.Name != "" {
+		object += "." + d.Name
+	}
+	switch d.Kind {
+	case MissingTable:
+		return fmt.Sprintf("tabela %s não existe no banco de dados", d.Table)
+	case ExtraTable:
+		return fmt.Sprintf("tabela %s não é criada pelas migrações", d.Table)
+	case MissingColumn:
+		return fmt.Sprintf("coluna %s não existe no banco de dados", object)
+	case ExtraColumn:
+		return fmt.Sprintf("coluna %s não é criada pelas migrações", object)
+	case ChangedColumn:
+		return fmt.Sprintf("coluna %s é %s no banco de dados; esperado %s", object, d.Actual, d.Expected)
+	case MissingIndex:
+		return fmt.Sprintf("índice %s de %s não existe no banco de dados", d.Name, d.Table)
+	case ExtraIndex:
+		return fmt.Sprintf("índice %s de %s não é criado pelas migrações", d.Name, d.Table)
+	case ChangedIndex:
+		return fmt.Sprintf("índice %s de %s é %s no banco de dados; esperado %s", d.Name, d.Table, d.Actual, d.Expected)
+	case MissingConstraint:
+		return fmt.Sprintf("restrição %s de %s não existe no banco de dados", d.Name, d.Table)
+	case ExtraConstraint:
+		return fmt.Sprintf("restrição %s de %s não é criada pelas migrações", d.Name, d.Table)
+	case ChangedConstraint:
+		return fmt.Sprintf("restrição %s de %s é %s no banco de dados; esperado %s", d.Name, d.Table, d.Actual, d.Expected)
+	}
+	return string(d.Kind) + " " + object
+}
+
+// Compare compara a estrutura esperada com a encontrada no banco de dados e retorna as divergências, agrupadas
+// por tabela em ordem alfabética. As colunas, os índices e as restrições são comparados pela definição gerada
+// no dialeto d, de modo que tipos equivalentes não sejam apontados como divergência.
+// As tabelas que não existem de um dos lados são apontadas sem as suas colunas e restrições.
+func Compare(d dialect.Dialect, expected []config.Schema, actual []config.Schema) []Difference {
+	expectedByName, actualByName := byName(expected), byName(actual)
+	names := make([]string, 0, len(expectedByName)+len(actualByName))
+	for name := range expectedByName {
+		names = append(names, name)
+	}
+	for name := range actualByName {
+		if _, ok := expectedByName[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	differences := []Difference{}
+	for _, name := range names {
+		want, inExpected := expectedByName[name]
+		got, inActual := actualByName[name]
+		switch {
+		case !inActual:
+			differences = append(differences, Difference{Kind: MissingTable, Table: name})
+		case !inExpected:
+			differences = append(differences, Difference{Kind: ExtraTable, Table: name})
+		default:
+			differences = append(differences, compareObjects(name, columns(d, want), columns(d, got), MissingColumn, ExtraColumn, ChangedColumn)...)
+			differences = append(differences, compareObjects(name, indexes(want), indexes(got), MissingIndex, ExtraIndex, ChangedIndex)...)
+			differences = append(differences, compareObjects(name, constraints(d, want), constraints(d, got), MissingConstraint, ExtraConstraint, ChangedConstraint)...)
+		}
+	}
+	return differences
+}
+
+// object é uma coluna, um índice ou uma restrição, com o nome e a definição usada na comparação.
+type object struct {
+	name       string
+	definition string
+}
+
+// compareObjects compara os objetos esperados com os encontrados, pelo nome: primeiro os esperados, na ordem
+// em que foram declarados, e depois os que só existem no banco.
+func compareObjects(table string, expected []object, actual []object, missing Kind, extra Kind, changed Kind) []Difference {
+	actualByName := make(map[string]string, len(actual))
+	for _, o := range actual {
+		actualByName[o.name] = o.definition
+	}
+	expectedNames := make(map[string]bool, len(expected))
+
+	var differences []Difference
+	for _, o := range expected {
+		expectedNames[o.name] = true
+		definition, ok := actualByName[o.name]
+		switch {
+		case !ok:
+			differences = append(differences, Difference{Kind: missing, Table: table, Name: o.name, Expected: o.definition})
+		case diff.Normalize(definition) != diff.Normalize(o.definition):
+			differences = append(differences, Difference{Kind: changed, Table: table, Name: o.name, Expected: o.definition, Actual: definition})
+		}
+	}
+	for _, o := range actual {
+		if !expectedNames[o.name] {
+			differences = append(differences, Difference{Kind: extra, Table: table, Name: o.name, Actual: o.definition})
+		}
+	}
+	return differences
+}
+
+// columns retorna as colunas do schema com a definição gerada no dialeto.
+func columns(d dialect.Dialect, schema config.Schema) []object {
+	var objects []object
+	for _, column := range schema.OrderedColumns() {
+		objects = append(objects, object{column.Name, d.ColumnDefinition(column)})
+	}
+	return objects
+}
+
+// indexes retorna os índices do schema, já com o nome padrão aplicado.
+func indexes(schema config.Schema) []object {
+	var objects []object
+	for _, index := range schema.Indexes {
+		definition := "(" + strings.Join(index.Columns, ", ") + ")"
+		if index.Unique {
+			definition = "UNIQUE " + definition
+		}
+		objects = append(objects, object{schema.IndexName(index), definition})
+	}
+	return objects
+}
+
+// constraints retorna as restrições UNIQUE e CHECK e as chaves estrangeiras do schema, escritas no dialeto.
+func constraints(d dialect.Dialect, schema config.Schema) []object {
+	var objects []object
+	for _, constraint := range append(dialect.Constraints(d, schema), dialect.ForeignKeys(d, schema)...) {
+		objects = append(objects, object{constraint.Name, constraint.Definition})
+	}
+	return objects
+}
+
+// byName retorna os schemas pelo nome da tabela.
+func byName(schemas []config.Schema) map[string]config.Schema {
+	result := make(map[string]config.Schema, len(schemas))
+	for _, schema := range schemas {
+		result[schema.TableName] = schema
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package drift_test
+
+import (
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drift"
+	"github.com/stretchr/testify/assert"
+)
+
+// users retorna a tabela users esperada pelas migrações nos testes.
+func users() config.Schema {
+	return config.Schema{
+		TableName: "users",
+		Columns: []config.Column{
+			{Name: "id", DataType: config.Integer, PrimaryKey: true},
+			{Name: "name", DataType: config.String, Length: 50},
+			{Name: "email", DataType: config.String, Length: 100},
+		},
+		Indexes:           []config.Index{{Columns: []string{"name"}}},
+		UniqueConstraints: []config.UniqueConstraint{{Columns: []string{"name", "email"}}},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	expected := []config.Schema{users(), {TableName: "posts", Columns: []config.Column{{Name: "id", DataType: config.Integer}}}}
+
+	// No banco: name alterada, email removida, phone acrescentada, índice trocado e uma tabela a mais
+	actual := users()
+	actual.Columns = []config.Column{
+		{Name: "id", DataType: config.Integer, PrimaryKey: true},
+		{Name: "name", DataType: config.String, Length: 80, Nullable: true},
+		{Name: "phone", DataType: config.String, Length: 20, Nullable: true},
+	}
+	actual.Indexes = []config.Index{{Columns: []string{"phone"}}}
+	audit := config.Schema{TableName: "audit", Columns: []config.Column{{Name: "id", DataType: config.Integer}}}
+
+	differences := drift.Compare(dialect.PostgreSQL, expected, []config.Schema{actual, audit})
+	assert.Equal(t, []drift.Difference{
+		{Kind: drift.ExtraTable, Table: "audit"},
+		{Kind: drift.MissingTable, Table: "posts"},
+		{Kind: drift.ChangedColumn, Table: "users", Name: "name", Expected: "VARCHAR(50) NOT NULL", Actual: "VARCHAR(80)"},
+		{Kind: drift.MissingColumn, Table: "users", Name: "email", Expected: "VARCHAR(100) NOT NULL"},
+		{Kind: drift.ExtraColumn, Table: "users", Name: "phone", Actual: "VARCHAR(20)"},
+		{Kind: drift.MissingIndex, Table: "users", Name: "idx_users_name", Expected: "(name)"},
+		{Kind: drift.ExtraIndex, Table: "users", Name: "idx_users_phone", Actual: "(phone)"},
+	}, differences)
+	assert.Equal(t, "coluna users.name é VARCHAR(80) no banco de dados; esperado VARCHAR(50) NOT NULL", differences[2].String())
+
+	// Tipos diferentes que o dialeto gera da mesma forma não são divergências
+	boolean := users()
+	boolean.Columns[1] = config.Column{Name: "name", DataType: config.Boolean}
+	integer := users()
+	integer.Columns[1] = config.Column{Name: "name", DataType: config.Integer}
+	assert.Empty(t, drift.Compare(dialect.SQLite, []config.Schema{boolean}, []config.Schema{integer}))
+}
@@ -0,0 +1,174 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// Driver integra um banco de dados ao sistema de migrações.
+// Os drivers são registrados pelo nome com Register e escolhidos em ConfigDB. Podem implementar também
+// Introspector, para a leitura da estrutura das tabelas.
+type Driver interface {
+	// Open abre a conexão com o banco de dados a partir das configurações fornecidas.
+	Open(cfg config.Cfg) (*sql.DB, error)
+	// Dialect retorna o dialeto SQL do banco de dados.
+	Dialect() dialect.Dialect
+	// Lock obtém o lock de migração identificado por name, aguardando até o cancelamento de ctx,
+	// e retorna a função que libera o lock.
+	Lock(ctx context.Context, db *sql.DB, name string) (func() error, error)
+	// CreateHistoryTable retorna o comando que cria a tabela de histórico de migrações com o nome informado.
+	CreateHistoryTable(table string) string
+}
+
+// SQLDriver implementa um Driver para qualquer banco com driver database/sql, a partir do nome do driver
+// e do dialeto. Usa a tabela de lock (veja TableLock) e cria a tabela de histórico com tipos comuns.
+// Pode ser usado diretamente no Register ou incorporado em outro tipo que substitua alguns dos métodos.
+//
+// Exemplo de uso:
+//
+//	drivers.Register("clickhouse", drivers.SQLDriver{DriverName: "clickhouse", SQLDialect: meuDialeto})
+type SQLDriver struct {
+	DriverName string          // Nome do driver database/sql usado em sql.Open
+	SQLDialect dialect.Dialect // Dialeto do banco; quando nil, é usado dialect.Generic
+}
+
+// Open abre a conexão usando cfg.DSN como string de conexão e verifica se ela é bem-sucedida.
+func (d SQLDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	db, err := sql.Open(d.DriverName, cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Dialect retorna o dialeto configurado, ou dialect.Generic.
+func (d SQLDriver) Dialect() dialect.Dialect {
+	if d.SQLDialect == nil {
+		return dialect.Generic
+	}
+	return d.SQLDialect
+}
+
+// Lock obtém o lock de migração com a tabela de lock.
+func (d SQLDriver) Lock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	return TableLock(ctx, db, d.Dialect(), name)
+}
+
+// CreateHistoryTable retorna o comando de criação da tabela de histórico com tipos suportados pela maioria dos bancos.
+func (d SQLDriver) CreateHistoryTable(table string) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+    version BIGINT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at %s NOT NULL,
+    execution_time BIGINT NOT NULL,
+    success SMALLINT NOT NULL,
+    checksum VARCHAR(64),
+    dirty SMALLINT
+)`, table, d.Dialect().TimestampType())
+}
+
+// Introspector é implementado pelos drivers que leem a estrutura das tabelas existentes no banco de dados,
+// usada pelo baseline e pela detecção de divergências. É opcional, pois a leitura do catálogo depende de cada
+// banco de dados; os drivers que não o implementam continuam válidos para executar migrações.
+type Introspector interface {
+	// Introspect lê as tabelas existentes no banco de dados e retorna a estrutura de cada uma.
+	Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error)
+}
+
+// Introspect lê a estrutura das tabelas do banco de dados com o driver, se ele implementar Introspector.
+func Introspect(ctx context.Context, driver Driver, db *sql.DB) ([]config.Schema, error) {
+	introspector, ok := driver.(Introspector)
+	if !ok {
+		return nil, fmt.Errorf("O driver do banco de dados %s não suporta introspecção", driver.Dialect().Name())
+	}
+	return introspector.Introspect(ctx, db)
+}
+
+var (
+	registryMu sync.RWMutex
+	// registry guarda os drivers registrados, indexados pelo nome em minúsculas
+	registry = make(map[string]Driver)
+)
+
+// Register registra um driver com o nome informado (sem diferenciar maiúsculas de minúsculas),
+// tornando-o disponível em ConfigDB. Um registro com o mesmo nome substitui o anterior.
+func Register(name string, driver Driver) {
+	if driver == nil {
+		panic("drivers: Register com driver nil para " + name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = driver
+}
+
+// Lookup retorna o driver registrado com o nome informado.
+func Lookup(name string) (Driver, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	driver, ok := registry[strings.ToLower(name)]
+	return driver, ok
+}
+
+// Names retorna os nomes dos drivers registrados, em ordem alfabética.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open abre a conexão com o driver registrado com o nome informado.
+// A conexão não guarda o driver que a abriu: ForDB o identifica pelo tipo do driver database/sql, e drivers
+// de outros bancos devem acompanhar a conexão, como no Migrator (veja exec.WithDriver).
+func Open(name string, cfg config.Cfg) (*sql.DB, error) {
+	driver, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("Driver de banco de dados não suportado: %s", name)
+	}
+	return driver.Open(cfg)
+}
+
+// builtinTypes associa o prefixo do tipo dos drivers database/sql conhecidos ao nome do Driver registrado,
+// permitindo identificar conexões abertas diretamente com sql.Open.
+var builtinTypes = map[string]string{
+	"*mysql.":       "mysql",
+	"*pq.":          "postgresql",
+	"*sqlite3.":     "sqlite",
+	"*mssql.":       "sqlserver",
+	"*firebirdsql.": "firebirdsql",
+}
+
+// ForDB retorna o Driver correspondente a uma conexão, registrado para o tipo do seu driver database/sql.
+// Se nenhum for encontrado, retorna um SQLDriver com o dialeto genérico.
+func ForDB(db *sql.DB) Driver {
+	driverType := reflect.TypeOf(db.Driver()).String()
+	for prefix, name := range builtinTypes {
+		if strings.HasPrefix(driverType, prefix) {
+			if driver, ok := Lookup(name); ok {
+				return driver
+			}
+		}
+	}
+	return SQLDriver{}
+}
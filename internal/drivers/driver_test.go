@@ -0,0 +1,73 @@
+package drivers_test
+
+import (
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+// update regrava os arquivos golden com a saída atual: go test ./internal/drivers -update
+var update = flag.Bool("update", false, "regrava os arquivos golden em testdata")
+
+// assertGolden compara a saída com o arquivo testdata/<name>.golden.
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		assert.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err, "Arquivo golden não encontrado; execute os testes com -update")
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestCreateHistoryTable(t *testing.T) {
+	// A coluna applied_at usa um tipo de data e hora sem atualização automática em cada banco
+	for _, name := range []string{"mysql", "postgresql", "sqlite", "sqlserver", "firebirdsql"} {
+		t.Run(name, func(t *testing.T) {
+			driver, ok := drivers.Lookup(name)
+			assert.True(t, ok, "Driver não encontrado")
+			assertGolden(t, name+"_history", driver.CreateHistoryTable("schema_migrations"))
+		})
+	}
+}
+
+func TestIsUndefinedObject(t *testing.T) {
+	// Tabelas e colunas inexistentes, identificadas pelo código do erro
+	assert.True(t, drivers.IsUndefinedObject(&pq.Error{Code: "42P01", Message: `relation "schema_migrations" does not exist`}))
+	assert.True(t, drivers.IsUndefinedObject(&pq.Error{Code: "42703", Message: `column "dirty" does not exist`}))
+	assert.True(t, drivers.IsUndefinedObject(&mysql.MySQLError{Number: 1146, Message: "Table 'app.schema_migrations' doesn't exist"}))
+	assert.True(t, drivers.IsUndefinedObject(&mysql.MySQLError{Number: 1054, Message: "Unknown column 'dirty' in 'field list'"}))
+	assert.True(t, drivers.IsUndefinedObject(mssql.Error{Number: 208, Message: "Invalid object name 'schema_migrations'."}))
+	assert.True(t, drivers.IsUndefinedObject(fmt.Errorf("ORA-00942: table or view does not exist")))
+
+	// Falhas de conexão e de acesso não são confundidas com uma tabela inexistente
+	assert.False(t, drivers.IsUndefinedObject(&pq.Error{Code: "3D000", Message: `database "app" does not exist`}))
+	assert.False(t, drivers.IsUndefinedObject(&pq.Error{Code: "28000", Message: `role "app" does not exist`}))
+	assert.False(t, drivers.IsUndefinedObject(&mysql.MySQLError{Number: 1049, Message: "Unknown database 'app'"}))
+	assert.False(t, drivers.IsUndefinedObject(mssql.Error{Number: 4060, Message: "Cannot open database \"app\" requested by the login."}))
+	assert.False(t, drivers.IsUndefinedObject(errors.New("sql: database is closed")))
+
+	// No SQLite, a mensagem do erro é comparada
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("SELECT version FROM schema_migrations")
+	assert.True(t, drivers.IsUndefinedObject(err))
+	_, err = db.Exec("CREATE TABLE schema_migrations (version INTEGER)")
+	assert.NoError(t, err)
+	_, err = db.Exec("SELECT dirty FROM schema_migrations")
+	assert.True(t, drivers.IsUndefinedObject(err))
+}
@@ -0,0 +1,47 @@
+package drivers
+
+import (
+	"errors"
+	"strings"
+
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// undefinedMarkers são trechos, em minúsculas, das mensagens de tabela ou coluna inexistente dos bancos cujos
+// drivers não possuem um código de erro próprio para isso: SQLite ("no such table", "no such column"),
+// Oracle (ORA-00942, ORA-00904) e Firebird ("Table unknown", "Column unknown").
+var undefinedMarkers = []string{"no such table", "no such column", "ora-00942", "ora-00904", "table unknown", "column unknown"}
+
+// IsUndefinedObject indica se o erro de uma consulta foi causado por uma tabela ou coluna inexistente, e não
+// por outra falha, como uma conexão fechada, um banco de dados inacessível ou um usuário sem acesso.
+// Nos drivers que informam o código do erro, ele é comparado: 42P01 e 42703 no PostgreSQL, 1146 e 1054 no
+// MySQL e 208 e 207 no SQL Server. Nos demais, a mensagem é comparada com undefinedMarkers.
+func IsUndefinedObject(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "42P01" || pqErr.Code == "42703"
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1146 || mysqlErr.Number == 1054
+	}
+	var mssqlErr mssql.Error
+	if errors.As(err, &mssqlErr) {
+		return mssqlErr.Number == 208 || mssqlErr.Number == 207
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code != sqlite3.ErrError {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, marker := range undefinedMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,22 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/introspect"
+	_ "github.com/nakagami/firebirdsql"
 )
 
+func init() {
+	driver := firebirdDriver{SQLDriver{DriverName: "firebirdsql", SQLDialect: dialect.Firebird}}
+	Register("firebirdsql", driver)
+	Register("firebird", driver)
+}
+
 // DbFirebird estabelece uma conexão com um banco de dados Firebird utilizando as configurações fornecidas.
 // Recebe um struct Cfg contendo os detalhes de configuração do banco de dados, como usuário, senha, endereço e nome do banco de dados.
 // Retorna um ponteiro para sql.DB, que representa a conexão com o banco de dados, e um possível erro, se houver.
@@ -33,8 +43,12 @@ import (
 //   - A porta padrão para o Firebird é 3050. Altere o endereço e a porta conforme necessário.
 //   - Certifique-se de que o banco de dados Firebird esteja em execução e acessível no endereço especificado.
 //   - O usuário e a senha devem ser fornecidos de acordo com as configurações de segurança do seu banco de dados.
+//   - Se cfg.DSN for informado, ele é usado diretamente como string de conexão.
 func DbFirebird(cfg config.Cfg) (*sql.DB, error) {
-	connString := fmt.Sprintf("%s:%s@%s/%s", cfg.User, cfg.Passwd, cfg.Addr, cfg.DBName)
+	connString := cfg.DSN
+	if connString == "" {
+		connString = fmt.Sprintf("%s:%s@%s/%s", cfg.User, cfg.Passwd, cfg.Addr, cfg.DBName)
+	}
 
 	db, err := sql.Open("firebirdsql", connString)
 	if err != nil {
@@ -44,3 +58,14 @@ func DbFirebird(cfg config.Cfg) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// firebirdDriver integra o Firebird ao sistema de migrações, usando a tabela de lock como lock de migração.
+type firebirdDriver struct{ SQLDriver }
+
+func (firebirdDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return DbFirebird(cfg)
+}
+
+func (firebirdDriver) Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	return introspect.Firebird(ctx, db)
+}
@@ -0,0 +1,234 @@
+package drivers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// ErrLockTimeout é retornado quando o lock de migração não é obtido antes do fim do prazo.
+var ErrLockTimeout = errors.New("Tempo esgotado aguardando o lock de migração")
+
+var (
+	// LockLease é a validade do lock na tabela de lock; ele é renovado enquanto estiver em uso
+	LockLease = time.Minute
+	// LockPollInterval é o intervalo entre as tentativas de obter o lock na tabela de lock
+	LockPollInterval = 500 * time.Millisecond
+)
+
+// lockError converte a falha causada pelo fim do prazo do contexto em ErrLockTimeout.
+func lockError(ctx context.Context, err error) error {
+	if ctx.Err() == context.DeadlineExceeded || errors.Is(err, ErrLockTimeout) {
+		return ErrLockTimeout
+	}
+	return err
+}
+
+// advisoryLockKey retorna a chave numérica usada no pg_advisory_lock, derivada do nome do lock.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// postgreSQLLock obtém um advisory lock de sessão, mantendo a conexão reservada até a liberação.
+func postgreSQLLock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, lockError(ctx, err)
+	}
+
+	key := advisoryLockKey(name)
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		conn.Close()
+		return nil, lockError(ctx, err)
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key)
+		return err
+	}, nil
+}
+
+// mysqlLock obtém um lock nomeado com GET_LOCK. O nome inclui o banco de dados atual, pois no MySQL
+// os locks nomeados valem para todo o servidor.
+func mysqlLock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, lockError(ctx, err)
+	}
+
+	seconds := int64(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		seconds = int64(time.Until(deadline)/time.Second) + 1
+	}
+
+	var result sql.NullInt64
+	err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(CONCAT(DATABASE(), '.', ?), ?)", name, seconds).Scan(&result)
+	if err == nil && (!result.Valid || result.Int64 != 1) {
+		err = ErrLockTimeout
+	}
+	if err != nil {
+		conn.Close()
+		return nil, lockError(ctx, err)
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(CONCAT(DATABASE(), '.', ?))", name)
+		return err
+	}, nil
+}
+
+// mssqlServerLock obtém um lock de aplicação com sp_getapplock, associado à sessão.
+func mssqlServerLock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, lockError(ctx, err)
+	}
+
+	timeout := int64(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline).Milliseconds()
+	}
+
+	var result int
+	err = conn.QueryRowContext(ctx, `DECLARE @result INT;
+EXEC @result = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = @p2;
+SELECT @result`, name, timeout).Scan(&result)
+	if err == nil && result < 0 {
+		// -1 indica tempo esgotado; os demais valores negativos indicam falha ao obter o lock
+		err = ErrLockTimeout
+		if result != -1 {
+			err = fmt.Errorf("sp_getapplock retornou %d", result)
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, lockError(ctx, err)
+	}
+
+	return func() error {
+		defer conn.Close()
+		_, err := conn.ExecContext(context.Background(),
+			"EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'", name)
+		return err
+	}, nil
+}
+
+// TableLock obtém o lock de migração nos bancos sem suporte a locks nomeados, inserindo a única linha da
+// tabela <name>_lock, criada quando necessário. A linha tem uma validade (expires_at, em milissegundos desde
+// a época Unix) de LockLease, renovada enquanto o lock estiver em uso, para que um processo interrompido não
+// bloqueie as próximas execuções indefinidamente.
+func TableLock(ctx context.Context, db *sql.DB, d dialect.Dialect, name string) (func() error, error) {
+	table := name + "_lock"
+	if err := ensureLockTable(ctx, db, table); err != nil {
+		return nil, lockError(ctx, err)
+	}
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d:%d", hostname, os.Getpid(), rand.Int63())
+
+	deleteExpired := fmt.Sprintf("DELETE FROM %s WHERE expires_at < %s", table, d.Placeholder(1))
+	insert := fmt.Sprintf("INSERT INTO %s (id, locked_by, expires_at) VALUES (1, %s, %s)",
+		table, d.Placeholder(1), d.Placeholder(2))
+
+	for {
+		now := time.Now()
+		if _, err := db.ExecContext(ctx, deleteExpired, now.UnixMilli()); err != nil {
+			return nil, lockError(ctx, err)
+		}
+		// A inserção falha por violação de chave primária enquanto outro processo detém o lock; qualquer
+		// outra falha, como a perda da conexão ou a falta de permissão, é retornada sem aguardar
+		_, err := db.ExecContext(ctx, insert, owner, now.Add(LockLease).UnixMilli())
+		if err == nil {
+			break
+		}
+		if !isUniqueViolation(err) {
+			return nil, lockError(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, lockError(ctx, ctx.Err())
+		case <-time.After(LockPollInterval):
+		}
+	}
+
+	// Renova a validade do lock até que ele seja liberado
+	renew := fmt.Sprintf("UPDATE %s SET expires_at = %s WHERE id = 1 AND locked_by = %s",
+		table, d.Placeholder(1), d.Placeholder(2))
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(LockLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				db.Exec(renew, time.Now().Add(LockLease).UnixMilli(), owner)
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		wg.Wait()
+		_, err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = 1 AND locked_by = %s", table, d.Placeholder(1)), owner)
+		return err
+	}, nil
+}
+
+// uniqueViolationMarkers são trechos, em minúsculas, das mensagens de violação de chave primária ou única
+// dos bancos suportados, como "UNIQUE constraint failed" (SQLite), "Duplicate entry" (MySQL),
+// "duplicate key value violates unique constraint" (PostgreSQL), "Violation of PRIMARY KEY constraint"
+// (SQL Server) e "violation of PRIMARY or UNIQUE KEY constraint" (Firebird).
+var uniqueViolationMarkers = []string{"unique", "duplicate", "primary key"}
+
+// isUniqueViolation indica se o erro é uma violação de chave primária ou única. Os drivers database/sql não
+// possuem um tipo de erro comum, por isso a mensagem é comparada.
+func isUniqueViolation(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, marker := range uniqueViolationMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureLockTable cria a tabela de lock caso ela ainda não exista.
+func ensureLockTable(ctx context.Context, db *sql.DB, table string) error {
+	exists := fmt.Sprintf("SELECT id FROM %s WHERE 1 = 0", table)
+	if _, err := db.ExecContext(ctx, exists); err == nil {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE %s (
+    id INTEGER NOT NULL PRIMARY KEY,
+    locked_by VARCHAR(255) NOT NULL,
+    expires_at BIGINT NOT NULL
+)`, table))
+	if err != nil {
+		// Outro processo pode ter criado a tabela ao mesmo tempo
+		if _, existsErr := db.ExecContext(ctx, exists); existsErr == nil {
+			return nil
+		}
+		return fmt.Errorf("Erro ao criar a tabela de lock %s: %v", table, err)
+	}
+	return nil
+}
@@ -1,13 +1,22 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/introspect"
 	_ "github.com/denisenkom/go-mssqldb"
 )
 
+func init() {
+	driver := mssqlServerDriver{SQLDriver{DriverName: "sqlserver", SQLDialect: dialect.SQLServer}}
+	Register("sqlserver", driver)
+	Register("mssql", driver)
+}
+
 // DbMSSQLServer estabelece uma conexão com um banco de dados Microsoft SQL Server utilizando as configurações fornecidas.
 // Recebe um struct Cfg contendo os detalhes de configuração do banco de dados, como usuário, senha, endereço e nome do banco de dados.
 // Retorna um ponteiro para sql.DB, que representa a conexão com o banco de dados, e um possível erro, se houver.
@@ -28,10 +37,15 @@ import (
 //	defer db.Close()
 //
 //	- Agora você pode usar 'db' para realizar operações no banco de dados Microsoft SQL Server.
+//
+// Se cfg.DSN for informado, ele é usado diretamente como string de conexão.
 func DbMSSQLServer(cfg config.Cfg) (*sql.DB, error) {
 	// Monta a string de conexão com o Microsoft SQL Server
-	connStr := fmt.Sprintf("server=%s;user id=%s;password=%s;port=%s;database=%s",
-		cfg.Addr, cfg.User, cfg.Passwd, cfg.Port, cfg.DBName)
+	connStr := cfg.DSN
+	if connStr == "" {
+		connStr = fmt.Sprintf("server=%s;user id=%s;password=%s;port=%s;database=%s",
+			cfg.Addr, cfg.User, cfg.Passwd, cfg.Port, cfg.DBName)
+	}
 
 	// Abre a conexão com o banco de dados Microsoft SQL Server
 	db, err := sql.Open("sqlserver", connStr)
@@ -48,3 +62,18 @@ func DbMSSQLServer(cfg config.Cfg) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// mssqlServerDriver integra o Microsoft SQL Server ao sistema de migrações, usando sp_getapplock como lock de migração.
+type mssqlServerDriver struct{ SQLDriver }
+
+func (mssqlServerDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return DbMSSQLServer(cfg)
+}
+
+func (mssqlServerDriver) Lock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	return mssqlServerLock(ctx, db, name)
+}
+
+func (mssqlServerDriver) Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	return introspect.SQLServer(ctx, db)
+}
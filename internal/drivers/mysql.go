@@ -1,13 +1,20 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/introspect"
 	"github.com/go-sql-driver/mysql"
 )
 
+func init() {
+	Register("mysql", mysqlDriver{SQLDriver{DriverName: "mysql", SQLDialect: dialect.MySQL}})
+}
+
 // DbMysql estabelece uma conexão com um banco de dados MySQL utilizando as configurações fornecidas.
 // Recebe um struct Cfg contendo os detalhes de configuração do banco de dados, como usuário, senha, endereço e nome do banco de dados.
 // Retorna um ponteiro para sql.DB, que representa a conexão com o banco de dados, e um possível erro, se houver.
@@ -34,6 +41,7 @@ import (
 //   - A porta padrão para o MySQL é 3306. Altere o endereço e a porta conforme necessário.
 //   - Certifique-se de que o banco de dados MySQL esteja em execução e acessível no endereço especificado.
 //   - O usuário e a senha devem ser fornecidos de acordo com as configurações de segurança do seu banco de dados.
+//   - Se cfg.DSN for informado, ele é usado diretamente como string de conexão.
 func DbMysql(cfg config.Cfg) (*sql.DB, error) {
 	cfgMysql := mysql.Config{
 		User:   cfg.User,
@@ -43,7 +51,12 @@ func DbMysql(cfg config.Cfg) (*sql.DB, error) {
 		DBName: cfg.DBName,
 	}
 
-	db, err := sql.Open("mysql", cfgMysql.FormatDSN())
+	dsn := cfg.DSN
+	if dsn == "" {
+		dsn = cfgMysql.FormatDSN()
+	}
+
+	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		fmt.Println("Erro ao conectar ao banco de dados:", err)
 		return nil, err
@@ -51,3 +64,18 @@ func DbMysql(cfg config.Cfg) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// mysqlDriver integra o MySQL ao sistema de migrações, usando GET_LOCK como lock de migração.
+type mysqlDriver struct{ SQLDriver }
+
+func (mysqlDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return DbMysql(cfg)
+}
+
+func (mysqlDriver) Lock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	return mysqlLock(ctx, db, name)
+}
+
+func (mysqlDriver) Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	return introspect.MySQL(ctx, db)
+}
@@ -1,13 +1,22 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/introspect"
 	_ "github.com/lib/pq"
 )
 
+func init() {
+	driver := postgreSQLDriver{SQLDriver{DriverName: "postgres", SQLDialect: dialect.PostgreSQL}}
+	Register("postgresql", driver)
+	Register("postgres", driver)
+}
+
 // DbPostgreSQL estabelece uma conexão com um banco de dados PostgreSQL utilizando as configurações fornecidas.
 // Recebe um struct Cfg contendo os detalhes de configuração do banco de dados, como usuário, senha, endereço e nome do banco de dados.
 // Retorna um ponteiro para sql.DB, que representa a conexão com o banco de dados, e um possível erro, se houver.
@@ -28,10 +37,15 @@ import (
 //	defer db.Close()
 //
 //	- Agora você pode usar 'db' para realizar operações no banco de dados PostgreSQL.
+//
+// Se cfg.DSN for informado, ele é usado diretamente como string de conexão.
 func DbPostgreSQL(cfg config.Cfg) (*sql.DB, error) {
 	// Monta a string de conexão com o PostgreSQL
-	connStr := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable",
-		cfg.User, cfg.Passwd, cfg.Addr, cfg.Port, cfg.DBName)
+	connStr := cfg.DSN
+	if connStr == "" {
+		connStr = fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s sslmode=disable",
+			cfg.User, cfg.Passwd, cfg.Addr, cfg.Port, cfg.DBName)
+	}
 
 	// Abre a conexão com o banco de dados PostgreSQL
 	db, err := sql.Open("postgres", connStr)
@@ -48,3 +62,18 @@ func DbPostgreSQL(cfg config.Cfg) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// postgreSQLDriver integra o PostgreSQL ao sistema de migrações, usando pg_advisory_lock como lock de migração.
+type postgreSQLDriver struct{ SQLDriver }
+
+func (postgreSQLDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return DbPostgreSQL(cfg)
+}
+
+func (postgreSQLDriver) Lock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	return postgreSQLLock(ctx, db, name)
+}
+
+func (postgreSQLDriver) Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	return introspect.PostgreSQL(ctx, db)
+}
@@ -1,11 +1,23 @@
 package drivers
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/introspect"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+func init() {
+	driver := sqliteDriver{SQLDriver{DriverName: "sqlite3", SQLDialect: dialect.SQLite}}
+	Register("sqlite", driver)
+	Register("sqlite3", driver)
+}
+
 // DbSQLite estabelece uma conexão com um banco de dados SQLite utilizando o caminho do arquivo do banco de dados fornecido.
 // Retorna um ponteiro para sql.DB, que representa a conexão com o banco de dados, e um possível erro, se houver.
 //
@@ -18,6 +30,10 @@ import (
 //	defer db.Close()
 //
 //	- Agora você pode usar 'db' para realizar operações no banco de dados SQLite.
+//
+// Observações:
+//   - Com o caminho ":memory:" (ou uma URI com mode=memory), o banco existe apenas em memória. Como cada
+//     conexão em memória é um banco diferente, o pool é limitado a uma única conexão, mantida aberta.
 func DbSQLite(dbPath string) (*sql.DB, error) {
 	// Abre a conexão com o banco de dados SQLite
 	db, err := sql.Open("sqlite3", dbPath)
@@ -25,6 +41,12 @@ func DbSQLite(dbPath string) (*sql.DB, error) {
 		return nil, err
 	}
 
+	if isSQLiteMemory(dbPath) {
+		db.SetMaxOpenConns(1)
+		db.SetConnMaxIdleTime(0)
+		db.SetConnMaxLifetime(0)
+	}
+
 	// Verifica se a conexão com o banco de dados é bem-sucedida
 	err = db.Ping()
 	if err != nil {
@@ -34,3 +56,27 @@ func DbSQLite(dbPath string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// isSQLiteMemory indica se o caminho se refere a um banco SQLite em memória.
+func isSQLiteMemory(dbPath string) bool {
+	return dbPath == ":memory:" || strings.Contains(dbPath, "mode=memory")
+}
+
+// sqliteDriver integra o SQLite ao sistema de migrações, usando a tabela de lock como lock de migração.
+// O caminho do arquivo do banco de dados é lido de cfg.DSN ou, se vazio, de cfg.Path.
+type sqliteDriver struct{ SQLDriver }
+
+func (sqliteDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	dbPath := cfg.DSN
+	if dbPath == "" {
+		dbPath = cfg.Path
+	}
+	if dbPath == "" {
+		return nil, fmt.Errorf("Informe o caminho do arquivo do banco de dados SQLite em Cfg.Path (ou \":memory:\")")
+	}
+	return DbSQLite(dbPath)
+}
+
+func (sqliteDriver) Introspect(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	return introspect.SQLite(ctx, db)
+}
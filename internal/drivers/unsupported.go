@@ -0,0 +1,26 @@
+package drivers
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+)
+
+func init() {
+	Register("mongodb", unsupportedDriver{name: "mongodb", connect: "DbMongoDB"})
+	Register("cassandra", unsupportedDriver{name: "cassandra", connect: "DbCassandra"})
+}
+
+// unsupportedDriver registra os bancos que não possuem driver database/sql, como MongoDB e Cassandra.
+// Eles continuam acessíveis pelas funções de conexão próprias (DbMongoDB, DbCassandra), mas não podem
+// ser usados pelo sistema de migrações, que executa arquivos SQL por meio de *sql.DB.
+type unsupportedDriver struct {
+	SQLDriver
+	name    string // Nome do banco de dados
+	connect string // Função de conexão própria do banco
+}
+
+func (d unsupportedDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return nil, fmt.Errorf("O banco de dados %s não possui driver database/sql e não suporta migrações SQL; use drivers.%s para conectar", d.name, d.connect)
+}
@@ -0,0 +1,126 @@
+package exec
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// ChecksumMismatchError indica que o arquivo de uma migração já aplicada foi alterado depois da sua execução.
+type ChecksumMismatchError struct {
+	Version  int64  // Versão da migração
+	Name     string // Nome do arquivo de migração
+	Expected string // Checksum registrado na tabela de histórico
+	Actual   string // Checksum do conteúdo atual do arquivo
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("O arquivo da migração já aplicada %s foi alterado (checksum registrado: %s, checksum atual: %s); "+
+		"revise a alteração e execute o repair para atualizar o checksum", e.Name, e.Expected, e.Actual)
+}
+
+// checksum calcula o SHA-256, em hexadecimal, do conteúdo de um arquivo de migração.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyChecksums compara o conteúdo atual dos arquivos das migrações aplicadas com os checksums do histórico.
+// Migrações registradas sem checksum (aplicadas antes da existência da coluna) não são verificadas.
+func verifyChecksums(conn *connection, src source.Source, migrations []source.Migration, history map[int64]AppliedMigration) error {
+	for _, m := range migrations {
+		applied, ok := history[m.Version]
+		if !ok || !applied.Success || applied.Checksum == "" {
+			continue
+		}
+		if _, ok := conn.goMigration(m.Version); ok {
+			continue
+		}
+
+		content, err := readUp(src, m)
+		if err != nil {
+			return err
+		}
+		if actual := checksum(content); actual != applied.Checksum {
+			return &ChecksumMismatchError{
+				Version:  m.Version,
+				Name:     m.Name,
+				Expected: applied.Checksum,
+				Actual:   actual,
+			}
+		}
+	}
+	return nil
+}
+
+// Repair recalcula os checksums das migrações aplicadas a partir do conteúdo atual dos arquivos,
+// aceitando as alterações feitas neles. Deve ser usado apenas depois de revisar essas alterações.
+// Retorna um possível erro, se houver.
+func Repair(db *sql.DB, migrationsDir string) error {
+	return RepairFrom(db, source.Dir(migrationsDir))
+}
+
+// RepairFS recalcula os checksums das migrações aplicadas a partir dos arquivos da raiz de fsys, assim como Repair.
+func RepairFS(db *sql.DB, fsys fs.FS) error {
+	return RepairFrom(db, source.FS(fsys))
+}
+
+// RepairFrom recalcula os checksums das migrações aplicadas a partir do conteúdo da origem src, assim como Repair.
+func RepairFrom(db *sql.DB, src source.Source) error {
+	return repair(context.Background(), newConnection(db), src, stdoutLogger)
+}
+
+// repair recalcula os checksums das migrações aplicadas, escrevendo em logger as migrações atualizadas.
+func repair(ctx context.Context, conn *connection, src source.Source, logger Logger) error {
+	// 1. Listar as migrações da origem
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return err
+	}
+
+	// 2. Obter o lock de migração, impedindo execuções simultâneas
+	release, err := acquireLock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// 3. Carregar o histórico
+	if err := ensureHistoryTable(ctx, conn); err != nil {
+		return err
+	}
+	history, err := loadHistory(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// 4. Atualizar os checksums que mudaram
+	for _, m := range migrations {
+		applied, ok := history[m.Version]
+		if !ok || !applied.Success {
+			continue
+		}
+		// As migrações Go não possuem conteúdo para o checksum
+		if _, ok := conn.goMigration(m.Version); ok {
+			continue
+		}
+
+		content, err := readUp(src, m)
+		if err != nil {
+			return err
+		}
+		if actual := checksum(content); actual != applied.Checksum {
+			if err := updateChecksum(conn.db, conn, m.Version, actual); err != nil {
+				return err
+			}
+			logger.Printf("Checksum atualizado: %s", m.Name)
+		}
+	}
+
+	return nil
+}
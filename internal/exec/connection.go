@@ -0,0 +1,51 @@
+package exec
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+)
+
+// connection reúne a conexão com o banco de dados, o driver que a atende e as configurações de um Migrator
+// usadas em cada operação: o dialeto, a tabela de histórico, o lock de migração e as migrações Go.
+type connection struct {
+	db          *sql.DB
+	driver      drivers.Driver
+	sqlDialect  dialect.Dialect // Dialeto configurado com WithDialect; quando nil, é usado o do driver
+	table       string          // Nome da tabela de histórico
+	lock        LockFunc        // Lock configurado com WithLock; quando nil, é usado o do driver
+	lockTimeout time.Duration   // Tempo máximo de espera pelo lock de migração
+
+	goMigrations map[int64]goMigration // Migrações Go, indexadas pela versão
+}
+
+// newConnection identifica o driver de uma conexão (veja drivers.ForDB) para as funções do pacote, com a
+// tabela de histórico padrão, o tempo de espera configurado com SetLockTimeout e as migrações Go registradas
+// com RegisterMigration.
+func newConnection(db *sql.DB) *connection {
+	return &connection{
+		db:           db,
+		driver:       drivers.ForDB(db),
+		table:        HistoryTable,
+		lockTimeout:  GetLockTimeout(),
+		goMigrations: registeredMigrations(),
+	}
+}
+
+// with retorna uma conexão com as mesmas configurações, inclusive o driver, para outro banco de dados do
+// mesmo tipo.
+func (c *connection) with(db *sql.DB) *connection {
+	other := *c
+	other.db = db
+	return &other
+}
+
+// dialect retorna o dialeto do banco de dados.
+func (c *connection) dialect() dialect.Dialect {
+	if c.sqlDialect != nil {
+		return c.sqlDialect
+	}
+	return c.driver.Dialect()
+}
@@ -0,0 +1,33 @@
+package exec
+
+import (
+	"fmt"
+)
+
+// DirtyError indica que o banco de dados está sujo: a execução de uma migração foi interrompida, ou falhou
+// fora de uma transação, e o banco pode ter ficado parcialmente migrado. Nenhuma migração é executada até que
+// a situação seja resolvida com Force, depois de corrigir o banco de dados manualmente.
+type DirtyError struct {
+	Version int64  // Versão da migração suja
+	Name    string // Nome da migração suja
+}
+
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("O banco de dados está sujo: a migração %s (versão %d) foi interrompida ou falhou fora de uma transação; "+
+		"corrija o banco de dados manualmente e execute o force com a versão em que ele ficou", e.Name, e.Version)
+}
+
+// checkDirty retorna um DirtyError com a menor versão suja do histórico, se houver.
+func checkDirty(history map[int64]AppliedMigration) error {
+	var dirty *AppliedMigration
+	for _, h := range history {
+		if h.Dirty && (dirty == nil || h.Version < dirty.Version) {
+			h := h
+			dirty = &h
+		}
+	}
+	if dirty == nil {
+		return nil
+	}
+	return &DirtyError{Version: dirty.Version, Name: dirty.Name}
+}
@@ -0,0 +1,81 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/drift"
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// DetectDrift compara a estrutura do banco de dados db com a estrutura resultante das suas migrações e retorna
+// as divergências, como tabelas, colunas, índices e restrições alterados manualmente depois das migrações.
+// A estrutura esperada é obtida executando, no banco vazio shadow, as migrações do diretório até a versão
+// atual de db; os dois bancos são então lidos com Introspect e comparados (veja drift.Compare). O banco shadow
+// deve ser do mesmo tipo de db e é alterado pela execução, por isso deve ser um banco descartável.
+// Retorna as divergências, vazias quando o banco corresponde às migrações, e um possível erro, se houver.
+func DetectDrift(db *sql.DB, shadow *sql.DB, migrationsDir string) ([]drift.Difference, error) {
+	return DetectDriftFrom(db, shadow, source.Dir(migrationsDir))
+}
+
+// DetectDriftFS compara a estrutura do banco de dados com a resultante das migrações da raiz de fsys, assim
+// como DetectDrift.
+func DetectDriftFS(db *sql.DB, shadow *sql.DB, fsys fs.FS) ([]drift.Difference, error) {
+	return DetectDriftFrom(db, shadow, source.FS(fsys))
+}
+
+// DetectDriftFrom compara a estrutura do banco de dados com a resultante das migrações da origem src, assim
+// como DetectDrift.
+func DetectDriftFrom(db *sql.DB, shadow *sql.DB, src source.Source) ([]drift.Difference, error) {
+	return detectDrift(context.Background(), newConnection(db), newConnection(shadow), src)
+}
+
+// detectDrift compara a estrutura do banco de dados da conexão conn com a resultante das migrações da origem
+// src, executadas no banco de dados de comparação da conexão shadowConn.
+func detectDrift(ctx context.Context, conn *connection, shadowConn *connection, src source.Source) ([]drift.Difference, error) {
+	// 1. Verificar se a origem das migrações pode ser lida e se os bancos são do mesmo tipo
+	if _, err := listMigrations(conn, src); err != nil {
+		return nil, err
+	}
+	if conn.dialect().Name() != shadowConn.dialect().Name() {
+		return nil, fmt.Errorf("O banco de dados de comparação (%s) deve ser do mesmo tipo do banco de dados (%s)", shadowConn.dialect().Name(), conn.dialect().Name())
+	}
+
+	// 2. Garantir que o banco de comparação está vazio
+	existing, err := introspect(ctx, shadowConn)
+	if err != nil {
+		return nil, err
+	}
+	shadowVersion, err := currentVersion(ctx, shadowConn)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 || shadowVersion > 0 {
+		return nil, fmt.Errorf("O banco de dados de comparação deve estar vazio")
+	}
+
+	// 3. Executar no banco de comparação as migrações até a versão atual do banco de dados
+	version, err := currentVersion(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if version > 0 {
+		if err := runMigrations(ctx, shadowConn, src, 0, version, writerLogger{io.Discard}); err != nil {
+			return nil, fmt.Errorf("Erro ao executar as migrações no banco de dados de comparação: %v", err)
+		}
+	}
+
+	// 4. Ler as duas estruturas e compará-las
+	expected, err := introspect(ctx, shadowConn)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := introspect(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	return drift.Compare(conn.dialect(), expected, actual), nil
+}
@@ -4,56 +4,218 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/diff"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
 )
 
 // GenerateMigration cria uma nova migração com base nas estruturas de dados fornecidas.
-// Ele cria um arquivo .sql com um nome que inclui um timestamp para garantir unicidade.
-// Retorna o nome do arquivo de migração criado e um possível erro, se houver.
+// Ele cria um par de arquivos com um nome que inclui um timestamp para garantir unicidade:
+// migration_<timestamp>.up.sql, com a criação das tabelas, e migration_<timestamp>.down.sql,
+// com a remoção das mesmas tabelas na ordem inversa.
+// Os comandos são gerados no dialeto indicado em Schema.DbType (veja dialectFor), na ordem de dependência:
+// primeiro todas as tabelas, depois as restrições UNIQUE e CHECK e os índices, e por fim as chaves estrangeiras,
+// que podem referenciar qualquer uma das tabelas. A reversão remove tudo na ordem inversa.
+// Retorna o nome do arquivo up da migração criada e um possível erro, se houver.
 func GenerateMigration(migrationsDir string, schemas ...config.Schema) (string, error) {
-	// 1. Criar o arquivo .sql da migration
-	// - Gera um timestamp do momento atual.
-	// - Cria um nome para a migração usando o timestamp.
-	// - Cria um arquivo com o nome da migração no diretório de migrações.
-	timestamp := time.Now().Format("20060102150405")
-	migrationFileName := fmt.Sprintf("migration_%s.sql", timestamp)
-	file, err := os.Create(filepath.Join(migrationsDir, migrationFileName))
+	// 1. Definir os nomes dos arquivos da migration
+	// - Gera um timestamp do momento atual, posterior às versões já existentes (veja nextVersion).
+	// - Cria os nomes dos arquivos up e down usando o timestamp.
+	timestamp, err := nextVersion(migrationsDir)
 	if err != nil {
 		return "", err
 	}
-	defer file.Close()
+	baseName := fmt.Sprintf("migration_%s", timestamp)
 
-	// 2. Definir o conteúdo da migração SQL
+	// 2. Definir o conteúdo da migração SQL, no dialeto de cada schema
+	dialects := make([]dialect.Dialect, len(schemas))
 	migrationContent := ""
-	for _, schema := range schemas {
-		migrationContent += fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n", schema.TableName)
-		if schema.DbType == "FirebirdSql" {
-			migrationContent = fmt.Sprintf("CREATE TABLE %s (\n", schema.TableName)
+	for i, schema := range schemas {
+		d, err := dialectFor(schema.DbType)
+		if err != nil {
+			return "", err
+		}
+		dialects[i] = d
+		migrationContent += d.CreateTable(schema) + "\n"
+	}
+	constraints, foreignKeys := "", ""
+	for i, schema := range schemas {
+		constraints += dialect.CreateConstraints(dialects[i], schema)
+		foreignKeys += dialect.CreateForeignKeys(dialects[i], schema)
+	}
+	migrationContent += blockOf(constraints) + blockOf(foreignKeys)
+
+	// 3. Definir o conteúdo da reversão, removendo as chaves estrangeiras, as restrições e as tabelas
+	// na ordem inversa da criação
+	foreignKeys, constraints = "", ""
+	tables := ""
+	for i := len(schemas) - 1; i >= 0; i-- {
+		foreignKeys += dialect.DropForeignKeys(dialects[i], schemas[i])
+		constraints += dialect.DropConstraints(dialects[i], schemas[i])
+		tables += dialects[i].DropTable(schemas[i].TableName)
+	}
+	downContent := blockOf(foreignKeys) + blockOf(constraints) + tables
+
+	// 4. Escrever o conteúdo da migração nos arquivos
+	if err := writeMigrationFiles(migrationsDir, baseName, migrationContent, downContent); err != nil {
+		return "", err
+	}
+
+	return baseName + upSuffix, nil
+}
+
+// blockOf separa um bloco de comandos do seguinte com uma linha em branco. Um bloco vazio é omitido.
+func blockOf(statements string) string {
+	if statements == "" {
+		return ""
+	}
+	return statements + "\n"
+}
+
+// GenerateDiffMigration compara a versão anterior dos schemas com a desejada e cria o par de arquivos de migração
+// com as diferenças: migration_<timestamp>.up.sql, com os comandos ALTER TABLE (e CREATE e DROP TABLE das tabelas
+// acrescentadas ou removidas), e migration_<timestamp>.down.sql, que desfaz as mudanças (veja diff.Compare).
+// Os pontos que precisam de revisão, como possíveis renomeações de colunas, são retornados e também escritos
+// como comentários no início do arquivo up.
+// Retorna o nome do arquivo up da migração criada, os avisos e um possível erro, se houver.
+func GenerateDiffMigration(migrationsDir string, previous []config.Schema, desired []config.Schema) (string, []string, error) {
+	// 1. Definir o dialeto, que deve ser o mesmo em todos os schemas
+	var d dialect.Dialect
+	for _, schema := range append(append([]config.Schema{}, desired...), previous...) {
+		schemaDialect, err := dialectFor(schema.DbType)
+		if err != nil {
+			return "", nil, err
 		}
-		fields := schema.Fields
-		numFields := len(fields)
-		i := 0
-		for fieldName, fieldType := range fields {
-			// Adiciona o campo com o tipo correspondente
-			migrationContent += fmt.Sprintf("    %s %s", fieldName, fieldType)
-			// Se não for o último campo, adiciona vírgula
-			// Poís a vírgula no ultimo campo, ocasiona um  erro de sintaxe no SQL
-			if i < numFields-1 {
-				migrationContent += ","
-			}
-			migrationContent += "\n"
-			i++
+		if d != nil && schemaDialect.Name() != d.Name() {
+			return "", nil, fmt.Errorf("Os schemas comparados devem usar o mesmo DbType: %s e %s", d.Name(), schemaDialect.Name())
 		}
-		migrationContent += ");\n\n"
+		d = schemaDialect
 	}
+	if d == nil {
+		return "", nil, fmt.Errorf("Nenhum schema informado para comparação")
+	}
+
+	// 2. Comparar as versões dos schemas
+	changes := diff.Compare(previous, desired)
+	if changes.Empty() {
+		return "", changes.Warnings, fmt.Errorf("Não há diferenças entre os schemas comparados")
+	}
+
+	// 3. Definir o conteúdo dos arquivos, com os avisos no início da migração
+	migrationContent := ""
+	for _, warning := range changes.Warnings {
+		migrationContent += dialect.ReviewComment("%s", warning)
+	}
+	migrationContent += diff.SQL(d, changes.Up)
+	downContent := diff.SQL(d, changes.Down)
 
-	// 3. Escrever o conteúdo da migração no arquivo
-	_, err = file.WriteString(migrationContent)
+	// 4. Escrever o conteúdo da migração nos arquivos
+	timestamp, err := nextVersion(migrationsDir)
 	if err != nil {
+		return "", nil, err
+	}
+	baseName := fmt.Sprintf("migration_%s", timestamp)
+	if err := writeMigrationFiles(migrationsDir, baseName, migrationContent, downContent); err != nil {
+		return "", nil, err
+	}
+
+	return baseName + upSuffix, changes.Warnings, nil
+}
+
+// dialectFor retorna o dialeto usado na geração de um Schema a partir do seu DbType: o dialeto do driver
+// registrado com esse nome (veja drivers.Register) ou, para bancos sem driver como o Oracle, o dialeto
+// com esse nome. Um DbType vazio usa o dialeto genérico.
+func dialectFor(dbType string) (dialect.Dialect, error) {
+	if dbType == "" {
+		return dialect.Generic, nil
+	}
+	if driver, ok := drivers.Lookup(dbType); ok {
+		return driver.Dialect(), nil
+	}
+	if d, ok := dialect.ByName(dbType); ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("Tipo de banco de dados não suportado na geração de migrações: %s", dbType)
+}
+
+// invalidNameChars corresponde aos caracteres que não podem fazer parte do nome de uma migração criada por CreateMigration.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// CreateMigration cria um par de arquivos de migração vazios, migration_<timestamp>_<nome>.up.sql e
+// migration_<timestamp>_<nome>.down.sql, para serem preenchidos manualmente.
+// O nome é convertido para minúsculas, com os demais caracteres substituídos por "_".
+// Retorna o nome do arquivo up criado e um possível erro, se houver.
+func CreateMigration(migrationsDir string, name string) (string, error) {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", fmt.Errorf("Nome de migração inválido")
+	}
+
+	timestamp, err := nextVersion(migrationsDir)
+	if err != nil {
+		return "", err
+	}
+	baseName := fmt.Sprintf("migration_%s_%s", timestamp, name)
+
+	if err := writeMigrationFiles(migrationsDir, baseName, "", ""); err != nil {
 		return "", err
 	}
 
-	return migrationFileName, nil
+	return baseName + upSuffix, nil
+}
+
+// nextVersion retorna a versão de uma nova migração do diretório: o timestamp do momento atual ou, se ele não
+// for maior que a última versão existente (como em duas migrações criadas no mesmo segundo), essa versão
+// mais um. Assim, a nova migração nunca repete uma versão e é sempre a última a ser executada.
+func nextVersion(migrationsDir string) (string, error) {
+	version, err := strconv.ParseInt(time.Now().Format("20060102150405"), 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	migrations, err := source.Dir(migrationsDir).Migrations()
+	if err != nil {
+		return "", err
+	}
+	if len(migrations) > 0 {
+		if latest := migrations[len(migrations)-1].Version; version <= latest {
+			version = latest + 1
+		}
+	}
+	return strconv.FormatInt(version, 10), nil
+}
+
+// writeMigrationFiles cria os arquivos up e down de uma migração, com o nome base e os conteúdos fornecidos.
+// Arquivos já existentes nunca são sobrescritos: nesse caso, ou se o arquivo down não puder ser criado,
+// nenhum arquivo da migração é mantido e o erro é retornado.
+func writeMigrationFiles(migrationsDir string, baseName string, upContent string, downContent string) error {
+	upPath := filepath.Join(migrationsDir, baseName+upSuffix)
+	if err := writeMigrationFile(upPath, upContent); err != nil {
+		return err
+	}
+	if err := writeMigrationFile(filepath.Join(migrationsDir, baseName+downSuffix), downContent); err != nil {
+		os.Remove(upPath)
+		return err
+	}
+	return nil
+}
+
+// writeMigrationFile cria o arquivo de migração no caminho informado com o conteúdo fornecido. Retorna erro
+// se o arquivo já existir.
+func writeMigrationFile(path string, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("Erro ao criar o arquivo de migração: %v", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	return err
 }
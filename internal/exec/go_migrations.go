@@ -0,0 +1,144 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// MigrationFunc é uma migração escrita em Go, executada dentro da transação tx, que também registra
+// a migração na tabela de histórico.
+type MigrationFunc func(ctx context.Context, tx *sql.Tx) error
+
+// GoMigration é uma migração escrita em Go, para mudanças que não podem ser expressas em SQL, como o
+// preenchimento de dados com regras da aplicação (veja WithGoMigrations).
+type GoMigration struct {
+	Version int64         // Versão da migração, que define a ordem de execução junto com as da origem
+	Up      MigrationFunc // Função que aplica a migração
+	Down    MigrationFunc // Função que reverte a migração; nil se a migração não puder ser revertida
+}
+
+// goMigration é uma migração Go de uma conexão, com o nome registrado na tabela de histórico.
+type goMigration struct {
+	name string
+	up   MigrationFunc
+	down MigrationFunc
+}
+
+// goMigrationSet valida as migrações Go e as indexa pela versão.
+func goMigrationSet(migrations []GoMigration) (map[int64]goMigration, error) {
+	set := make(map[int64]goMigration, len(migrations))
+	for _, m := range migrations {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("Versão inválida na migração Go: %d", m.Version)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("A migração Go da versão %d não possui a função up", m.Version)
+		}
+		if _, ok := set[m.Version]; ok {
+			return nil, fmt.Errorf("Versão %d duplicada nas migrações Go", m.Version)
+		}
+		set[m.Version] = goMigration{name: fmt.Sprintf("migration_%d.go", m.Version), up: m.Up, down: m.Down}
+	}
+	return set, nil
+}
+
+// Registro global das migrações Go, usado apenas pelas funções do pacote (veja RegisterMigration).
+var (
+	goMigrationsMu sync.RWMutex
+	goMigrations   = make(map[int64]goMigration)
+)
+
+// RegisterMigration registra uma migração escrita em Go, para mudanças que não podem ser expressas em SQL,
+// como o preenchimento de dados com regras da aplicação. As migrações registradas são executadas na ordem
+// das versões junto com as da origem (arquivos .sql ou outra source.Source) e registradas no mesmo histórico,
+// com o nome migration_<versão>.go. Com down nil, a migração não pode ser revertida.
+// O registro vale apenas para as funções do pacote, como RunMigrations; um Migrator executa somente as
+// migrações Go configuradas com WithGoMigrations.
+//
+// Deprecated: o registro é compartilhado por todo o processo; use um Migrator criado com WithGoMigrations.
+func RegisterMigration(version int64, up MigrationFunc, down MigrationFunc) {
+	if version <= 0 {
+		panic(fmt.Sprintf("exec: RegisterMigration com versão inválida %d", version))
+	}
+	if up == nil {
+		panic(fmt.Sprintf("exec: RegisterMigration com função up nil para a versão %d", version))
+	}
+
+	goMigrationsMu.Lock()
+	defer goMigrationsMu.Unlock()
+	if _, ok := goMigrations[version]; ok {
+		panic(fmt.Sprintf("exec: RegisterMigration chamado duas vezes para a versão %d", version))
+	}
+	goMigrations[version] = goMigration{name: fmt.Sprintf("migration_%d.go", version), up: up, down: down}
+}
+
+// registeredMigrations retorna uma cópia das migrações Go registradas com RegisterMigration.
+func registeredMigrations() map[int64]goMigration {
+	goMigrationsMu.RLock()
+	defer goMigrationsMu.RUnlock()
+	registered := make(map[int64]goMigration, len(goMigrations))
+	for version, m := range goMigrations {
+		registered[version] = m
+	}
+	return registered
+}
+
+// goMigration retorna a migração Go da conexão com a versão informada.
+func (c *connection) goMigration(version int64) (goMigration, bool) {
+	m, ok := c.goMigrations[version]
+	return m, ok
+}
+
+// listMigrations lista as migrações da origem src junto com as migrações Go da conexão, ordenadas pela versão.
+// Retorna erro se uma migração Go tiver a mesma versão de uma migração da origem.
+func listMigrations(conn *connection, src source.Source) ([]source.Migration, error) {
+	migrations, err := src.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(conn.goMigrations) == 0 {
+		return migrations, nil
+	}
+
+	merged := make([]source.Migration, 0, len(migrations)+len(conn.goMigrations))
+	for _, m := range migrations {
+		if g, ok := conn.goMigrations[m.Version]; ok {
+			return nil, fmt.Errorf("Versão %d duplicada na migração %s e na migração Go %s", m.Version, m.Name, g.name)
+		}
+		merged = append(merged, m)
+	}
+	for version, g := range conn.goMigrations {
+		m := source.Migration{Version: version, Name: g.name}
+		if g.down != nil {
+			m.DownName = g.name
+		}
+		merged = append(merged, m)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Version < merged[j].Version
+	})
+	return merged, nil
+}
+
+// execGoMigration executa a função de uma migração Go e, em seguida, a função record, que atualiza o histórico,
+// em uma única transação, desfeita por completo em caso de falha.
+func execGoMigration(ctx context.Context, conn *connection, fn MigrationFunc, record func(execer) error) error {
+	tx, err := conn.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
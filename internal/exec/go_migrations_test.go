@@ -0,0 +1,141 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+	"github.com/stretchr/testify/assert"
+)
+
+// withGoMigrations limpa o registro de migrações Go ao final do teste.
+func withGoMigrations(t *testing.T) {
+	t.Cleanup(func() {
+		goMigrationsMu.Lock()
+		defer goMigrationsMu.Unlock()
+		goMigrations = make(map[int64]goMigration)
+	})
+}
+
+func TestGoMigrations(t *testing.T) {
+	withGoMigrations(t)
+	db, err := ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	src, err := source.Slice(
+		source.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER, name TEXT, slug TEXT);", Down: "DROP TABLE users;"},
+		source.SQLMigration{Version: 3, Name: "seed", Up: "INSERT INTO users (id, name) VALUES (2, 'Maria');", Down: "DELETE FROM users WHERE id = 2;"},
+	)
+	assert.NoError(t, err)
+
+	// A migração Go é executada entre as migrações SQL, na ordem das versões
+	RegisterMigration(2, func(ctx context.Context, tx *sql.Tx) error {
+		var count int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&count); err != nil {
+			return err
+		}
+		if count != 0 {
+			return fmt.Errorf("a migração seed foi executada antes")
+		}
+		_, err := tx.ExecContext(ctx, "INSERT INTO users (id, name, slug) VALUES (1, 'Luis', 'luis')")
+		return err
+	}, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = 1")
+		return err
+	})
+	assert.NoError(t, RunMigrationsFrom(db, src))
+
+	statuses, err := StatusFrom(db, src)
+	assert.NoError(t, err)
+	var names []string
+	for _, s := range statuses {
+		assert.True(t, s.Applied)
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"users", "migration_2.go", "seed"}, names)
+
+	// A reversão executa a função down e remove o registro do histórico
+	assert.NoError(t, RollbackFrom(db, src, 2))
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count))
+	assert.Equal(t, 0, count)
+	version, err := CurrentVersion(db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
+
+func TestGoMigrationFailure(t *testing.T) {
+	withGoMigrations(t)
+	db, err := ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	src, err := source.Slice(source.SQLMigration{Version: 1, Up: "CREATE TABLE users (id INTEGER);"})
+	assert.NoError(t, err)
+
+	// A falha desfaz a transação da migração Go e a migração não pode ser revertida sem a função down
+	RegisterMigration(2, func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO users (id) VALUES (1)"); err != nil {
+			return err
+		}
+		return fmt.Errorf("falha no preenchimento")
+	}, nil)
+	err = RunMigrationsFrom(db, src)
+	assert.EqualError(t, err, "Erro ao executar migração migration_2.go: falha no preenchimento")
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count))
+	assert.Equal(t, 0, count)
+
+	// Uma migração Go com a mesma versão de uma migração da origem é um erro
+	RegisterMigration(1, func(ctx context.Context, tx *sql.Tx) error { return nil }, nil)
+	_, err = listMigrations(newConnection(db), src)
+	assert.EqualError(t, err, "Versão 1 duplicada na migração migration_1 e na migração Go migration_1.go")
+
+	assert.Panics(t, func() { RegisterMigration(2, func(ctx context.Context, tx *sql.Tx) error { return nil }, nil) })
+	assert.Panics(t, func() { RegisterMigration(0, func(ctx context.Context, tx *sql.Tx) error { return nil }, nil) })
+}
+
+func TestMigratorGoMigrations(t *testing.T) {
+	withGoMigrations(t)
+	db, err := ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	src, err := source.Slice(source.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);"})
+	assert.NoError(t, err)
+	seed := GoMigration{Version: 2, Up: func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO users (id) VALUES (1)")
+		return err
+	}}
+
+	// As migrações do registro global não são executadas por um Migrator
+	RegisterMigration(3, func(ctx context.Context, tx *sql.Tx) error {
+		return fmt.Errorf("a migração do registro global não deveria ser executada")
+	}, nil)
+	m, err := New(WithDB(db), WithSource(src), WithLogger(nil), WithGoMigrations(seed))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Up(context.Background()))
+	version, err := m.Version(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+
+	// Outro Migrator, sem a migração Go, não a conhece
+	other, err := New(WithDB(db), WithSource(src), WithTableName("other_migrations"), WithLogger(nil))
+	assert.NoError(t, err)
+	plan, err := other.Plan(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, plan, 1) {
+		assert.Equal(t, int64(1), plan[0].Version)
+	}
+
+	// Migrações Go inválidas são recusadas na criação
+	_, err = New(WithDB(db), WithSource(src), WithGoMigrations(seed, seed))
+	assert.EqualError(t, err, "Versão 2 duplicada nas migrações Go")
+	_, err = New(WithDB(db), WithSource(src), WithGoMigrations(GoMigration{Version: 4}))
+	assert.EqualError(t, err, "A migração Go da versão 4 não possui a função up")
+}
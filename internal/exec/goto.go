@@ -0,0 +1,191 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// Goto leva o banco de dados exatamente à versão informada: reverte, da mais recente para a mais antiga, as
+// migrações aplicadas com versão maior e aplica as pendentes com versão menor ou igual a ela.
+// Com a versão zero, todas as migrações são revertidas.
+// Retorna um possível erro, se houver.
+func Goto(db *sql.DB, migrationsDir string, version int64) error {
+	return GotoFrom(context.Background(), db, source.Dir(migrationsDir), version)
+}
+
+// GotoFS leva o banco de dados exatamente à versão informada com as migrações da raiz de fsys, assim como Goto.
+func GotoFS(db *sql.DB, fsys fs.FS, version int64) error {
+	return GotoFrom(context.Background(), db, source.FS(fsys), version)
+}
+
+// GotoFrom leva o banco de dados exatamente à versão informada com as migrações da origem src, assim como Goto.
+// Antes de executar qualquer migração, verifica se a versão existe na origem e se todas as migrações a reverter
+// possuem arquivo down. Todo o percurso é feito com o lock de migração, e o cancelamento de ctx interrompe a
+// execução antes da próxima migração (as já executadas permanecem registradas no histórico).
+func GotoFrom(ctx context.Context, db *sql.DB, src source.Source, version int64) error {
+	return gotoVersion(ctx, newConnection(db), src, version, stdoutLogger)
+}
+
+// gotoVersion leva o banco de dados exatamente à versão informada, escrevendo o andamento em logger.
+func gotoVersion(ctx context.Context, conn *connection, src source.Source, version int64, logger Logger) error {
+	if version < 0 {
+		return fmt.Errorf("Versão inválida: %d", version)
+	}
+
+	// 1. Listar as migrações e verificar se a versão existe
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return err
+	}
+	if !hasVersion(migrations, version) {
+		return fmt.Errorf("A versão %d não existe nas migrações", version)
+	}
+
+	// 2. Obter o lock de migração, impedindo execuções simultâneas
+	release, err := acquireLock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// 3. Carregar o histórico e verificar se o banco não está sujo e os arquivos das migrações já aplicadas
+	if err := ensureHistoryTable(ctx, conn); err != nil {
+		return err
+	}
+	history, err := loadHistory(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if err := checkDirty(history); err != nil {
+		return err
+	}
+	if err := verifyChecksums(conn, src, migrations, history); err != nil {
+		return err
+	}
+
+	// 4. Definir o percurso e verificar se todas as reversões necessárias existem
+	reverted := revertibleMigrations(migrations, history, version)
+	if err := requireDown(reverted); err != nil {
+		return err
+	}
+	var applied []source.Migration
+	if version > 0 {
+		applied = pendingMigrations(migrations, history, 0, version)
+	}
+
+	// 5. Reverter as migrações com versão maior e aplicar as pendentes até a versão
+	for _, m := range reverted {
+		if err := revertMigration(ctx, conn, src, m, logger); err != nil {
+			return err
+		}
+	}
+	for _, m := range applied {
+		if err := applyMigration(ctx, conn, src, m, logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasVersion indica se a versão existe entre as migrações. A versão zero, anterior a todas, sempre existe.
+func hasVersion(migrations []source.Migration, version int64) bool {
+	if version == 0 {
+		return true
+	}
+	for _, m := range migrations {
+		if m.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Force registra o banco de dados como estando exatamente na versão informada, sem executar nenhuma migração:
+// as migrações até essa versão são marcadas como aplicadas e os registros de versões maiores são removidos.
+// Serve para sincronizar o histórico depois de uma intervenção manual no banco de dados, inclusive quando ele
+// está sujo (veja DirtyError): a migração suja passa a ser aplicada, se tiver versão menor ou igual à informada,
+// ou é removida do histórico.
+// A versão deve existir no diretório de migrações, ou ser zero para limpar o histórico.
+// Retorna um possível erro, se houver.
+func Force(db *sql.DB, migrationsDir string, version int64) error {
+	return ForceFrom(db, source.Dir(migrationsDir), version)
+}
+
+// ForceFS registra o banco de dados como estando exatamente na versão informada, entre as migrações da raiz de
+// fsys, assim como Force.
+func ForceFS(db *sql.DB, fsys fs.FS, version int64) error {
+	return ForceFrom(db, source.FS(fsys), version)
+}
+
+// ForceFrom registra o banco de dados como estando exatamente na versão informada, entre as migrações da origem
+// src, assim como Force.
+func ForceFrom(db *sql.DB, src source.Source, version int64) error {
+	return force(context.Background(), newConnection(db), src, version)
+}
+
+// force registra o banco de dados como estando exatamente na versão informada, sem executar nenhuma migração.
+func force(ctx context.Context, conn *connection, src source.Source, version int64) error {
+	// 1. Listar as migrações da origem e as migrações Go da conexão
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return err
+	}
+
+	// 2. Obter o lock de migração, impedindo execuções simultâneas
+	release, err := acquireLock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// 3. Carregar o histórico
+	if err := ensureHistoryTable(ctx, conn); err != nil {
+		return err
+	}
+	history, err := loadHistory(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if !hasVersion(migrations, version) {
+		return fmt.Errorf("A versão %d não existe no diretório de migrações", version)
+	}
+
+	// 4. Marcar como aplicadas as migrações até a versão informada
+	for _, m := range migrations {
+		if m.Version > version {
+			break
+		}
+		if applied, ok := history[m.Version]; ok && applied.Success && !applied.Dirty {
+			continue
+		}
+
+		record := AppliedMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now(), Success: true}
+		if _, ok := conn.goMigration(m.Version); !ok {
+			content, err := readUp(src, m)
+			if err != nil {
+				return err
+			}
+			record.Checksum = checksum(content)
+		}
+		if err := recordMigration(conn.db, conn, record); err != nil {
+			return err
+		}
+	}
+
+	// 5. Remover os registros das versões maiores
+	for _, h := range history {
+		if h.Version > version {
+			if err := deleteMigration(conn.db, conn, h.Version); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+)
+
+// HistoryTable é o nome padrão da tabela onde ficam registradas as migrações já aplicadas (veja WithTableName).
+const HistoryTable = "schema_migrations"
+
+// AppliedMigration representa uma linha da tabela de histórico de migrações.
+type AppliedMigration struct {
+	Version       int64         // Versão da migração (timestamp presente no nome do arquivo)
+	Name          string        // Nome do arquivo de migração
+	AppliedAt     time.Time     // Momento em que a migração foi executada
+	ExecutionTime time.Duration // Tempo gasto na execução
+	Success       bool          // Indica se a execução terminou sem erros
+	Checksum      string        // SHA-256 do conteúdo do arquivo no momento da execução
+	Dirty         bool          // Indica uma execução interrompida, ou com falha fora de uma transação (veja DirtyError)
+}
+
+// historyUpgrades lista as colunas incluídas na tabela de histórico depois da sua primeira versão.
+// Tabelas criadas por versões anteriores recebem essas colunas com ALTER TABLE.
+var historyUpgrades = []struct {
+	Column     string
+	Definition string
+}{
+	{"checksum", "VARCHAR(64)"},
+	{"dirty", "SMALLINT"},
+}
+
+// ensureHistoryTable cria a tabela de histórico caso ela ainda não exista, e inclui as colunas que faltarem,
+// inclusive nas tabelas criadas por drivers que não as definem.
+// A existência é verificada com uma consulta vazia, pois nem todos os bancos suportam CREATE TABLE IF NOT EXISTS.
+func ensureHistoryTable(ctx context.Context, conn *connection) error {
+	if _, err := conn.db.ExecContext(ctx, fmt.Sprintf("SELECT version FROM %s WHERE 1 = 0", conn.table)); err != nil {
+		if _, err := conn.db.ExecContext(ctx, conn.driver.CreateHistoryTable(conn.table)); err != nil {
+			return fmt.Errorf("Erro ao criar a tabela de histórico %s: %v", conn.table, err)
+		}
+	}
+	return upgradeHistoryTable(ctx, conn)
+}
+
+// upgradeHistoryTable inclui na tabela de histórico as colunas de historyUpgrades que ainda não existirem.
+func upgradeHistoryTable(ctx context.Context, conn *connection) error {
+	for _, upgrade := range historyUpgrades {
+		if _, err := conn.db.ExecContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", upgrade.Column, conn.table)); err == nil {
+			continue
+		}
+		_, err := conn.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD %s %s", conn.table, upgrade.Column, upgrade.Definition))
+		if err != nil {
+			return fmt.Errorf("Erro ao atualizar a tabela de histórico %s: %v", conn.table, err)
+		}
+	}
+	return nil
+}
+
+// existingHistory carrega a tabela de histórico sem alterar o banco de dados: sem a tabela, nenhuma migração
+// foi aplicada, e as colunas de historyUpgrades ainda não incluídas são lidas como vazias. Outros erros,
+// como os de uma conexão fechada, são retornados.
+func existingHistory(ctx context.Context, conn *connection) (map[int64]AppliedMigration, error) {
+	// 1. Verificar se a tabela existe
+	if _, err := conn.db.ExecContext(ctx, fmt.Sprintf("SELECT version FROM %s WHERE 1 = 0", conn.table)); err != nil {
+		if drivers.IsUndefinedObject(err) {
+			return map[int64]AppliedMigration{}, nil
+		}
+		return nil, fmt.Errorf("Erro ao ler a tabela de histórico %s: %v", conn.table, err)
+	}
+
+	// 2. Identificar as colunas incluídas depois da primeira versão da tabela que ainda não existem
+	missing := make(map[string]bool)
+	for _, upgrade := range historyUpgrades {
+		_, err := conn.db.ExecContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE 1 = 0", upgrade.Column, conn.table))
+		if err == nil {
+			continue
+		}
+		if !drivers.IsUndefinedObject(err) {
+			return nil, fmt.Errorf("Erro ao ler a tabela de histórico %s: %v", conn.table, err)
+		}
+		missing[upgrade.Column] = true
+	}
+
+	// 3. Ler o histórico
+	return readHistory(ctx, conn, missing)
+}
+
+// loadHistory lê a tabela de histórico e retorna as migrações registradas, indexadas pela versão.
+func loadHistory(ctx context.Context, conn *connection) (map[int64]AppliedMigration, error) {
+	return readHistory(ctx, conn, nil)
+}
+
+// readHistory lê a tabela de histórico, com as colunas de historyUpgrades indicadas em missing lidas como NULL.
+func readHistory(ctx context.Context, conn *connection, missing map[string]bool) (map[int64]AppliedMigration, error) {
+	upgrades := make([]string, len(historyUpgrades))
+	for i, upgrade := range historyUpgrades {
+		upgrades[i] = upgrade.Column
+		if missing[upgrade.Column] {
+			upgrades[i] = "NULL"
+		}
+	}
+	rows, err := conn.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT version, name, applied_at, execution_time, success, %s FROM %s", strings.Join(upgrades, ", "), conn.table))
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler a tabela de histórico %s: %v", conn.table, err)
+	}
+	defer rows.Close()
+
+	history := make(map[int64]AppliedMigration)
+	for rows.Next() {
+		var m AppliedMigration
+		var appliedAt scanTime
+		var executionTime int64
+		var success int
+		var checksum sql.NullString
+		var dirty sql.NullInt64
+		if err := rows.Scan(&m.Version, &m.Name, &appliedAt, &executionTime, &success, &checksum, &dirty); err != nil {
+			return nil, fmt.Errorf("Erro ao ler a tabela de histórico %s: %v", conn.table, err)
+		}
+		m.AppliedAt = time.Time(appliedAt)
+		m.ExecutionTime = time.Duration(executionTime) * time.Millisecond
+		m.Success = success == 1
+		m.Checksum = checksum.String
+		m.Dirty = dirty.Int64 == 1
+		history[m.Version] = m
+	}
+	return history, rows.Err()
+}
+
+// recordMigration grava o resultado da execução de uma migração na tabela de histórico.
+// Uma tentativa anterior com falha da mesma versão é substituída.
+func recordMigration(ex execer, conn *connection, m AppliedMigration) error {
+	if err := deleteMigration(ex, conn, m.Version); err != nil {
+		return err
+	}
+
+	d := conn.dialect()
+	query := fmt.Sprintf("INSERT INTO %s (version, name, applied_at, execution_time, success, checksum, dirty) VALUES (%s, %s, %s, %s, %s, %s, %s)",
+		conn.table,
+		d.Placeholder(1),
+		d.Placeholder(2),
+		d.Placeholder(3),
+		d.Placeholder(4),
+		d.Placeholder(5),
+		d.Placeholder(6),
+		d.Placeholder(7),
+	)
+	_, err := ex.Exec(query, m.Version, m.Name, m.AppliedAt.UTC(), m.ExecutionTime.Milliseconds(), flag(m.Success), m.Checksum, flag(m.Dirty))
+	if err != nil {
+		return fmt.Errorf("Erro ao registrar a migração %s no histórico: %v", m.Name, err)
+	}
+	return nil
+}
+
+// deleteMigration remove o registro de uma versão da tabela de histórico.
+func deleteMigration(ex execer, conn *connection, version int64) error {
+	_, err := ex.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = %s", conn.table, conn.dialect().Placeholder(1)), version)
+	if err != nil {
+		return fmt.Errorf("Erro ao remover a versão %d do histórico: %v", version, err)
+	}
+	return nil
+}
+
+// markDirty marca ou desmarca como suja a versão registrada na tabela de histórico.
+func markDirty(ex execer, conn *connection, version int64, dirty bool) error {
+	query := fmt.Sprintf("UPDATE %s SET dirty = %s WHERE version = %s",
+		conn.table, conn.dialect().Placeholder(1), conn.dialect().Placeholder(2))
+	if _, err := ex.Exec(query, flag(dirty), version); err != nil {
+		return fmt.Errorf("Erro ao atualizar a situação da versão %d no histórico: %v", version, err)
+	}
+	return nil
+}
+
+// flag converte um booleano para as colunas SMALLINT da tabela de histórico.
+func flag(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// updateChecksum substitui o checksum registrado para uma versão.
+func updateChecksum(ex execer, conn *connection, version int64, checksum string) error {
+	query := fmt.Sprintf("UPDATE %s SET checksum = %s WHERE version = %s",
+		conn.table, conn.dialect().Placeholder(1), conn.dialect().Placeholder(2))
+	if _, err := ex.Exec(query, checksum, version); err != nil {
+		return fmt.Errorf("Erro ao atualizar o checksum da versão %d: %v", version, err)
+	}
+	return nil
+}
+
+// scanTime lê colunas de data e hora independentemente de o driver entregar time.Time ou texto
+// (o driver MySQL, por exemplo, só converte datas quando a DSN possui parseTime=true).
+type scanTime time.Time
+
+// Scan implementa a interface sql.Scanner.
+func (t *scanTime) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case time.Time:
+		*t = scanTime(v)
+		return nil
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case nil:
+		*t = scanTime(time.Time{})
+		return nil
+	default:
+		return fmt.Errorf("Tipo de data não suportado: %T", src)
+	}
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", "2006-01-02 15:04:05.999999999-07:00"} {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			*t = scanTime(parsed)
+			return nil
+		}
+	}
+	return fmt.Errorf("Formato de data não reconhecido: %s", text)
+}
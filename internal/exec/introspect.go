@@ -0,0 +1,35 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+)
+
+// Introspect lê as tabelas existentes no banco de dados e retorna a estrutura de cada uma, em ordem alfabética,
+// sem a tabela de histórico e a tabela de lock do sistema de migrações. Os schemas retornados podem ser
+// passados para GenerateMigration, gerando uma migração inicial de um banco de dados já existente.
+// Retorna um possível erro, se houver.
+func Introspect(db *sql.DB) ([]config.Schema, error) {
+	return introspect(context.Background(), newConnection(db))
+}
+
+// introspect lê as tabelas do banco de dados da conexão, sem a sua tabela de histórico e a tabela de lock.
+func introspect(ctx context.Context, conn *connection) ([]config.Schema, error) {
+	schemas, err := drivers.Introspect(ctx, conn.driver, conn.db)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler a estrutura do banco de dados: %v", err)
+	}
+
+	tables := make([]config.Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		if schema.TableName == conn.table || schema.TableName == conn.table+"_lock" {
+			continue
+		}
+		tables = append(tables, schema)
+	}
+	return tables, nil
+}
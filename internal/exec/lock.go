@@ -0,0 +1,67 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+)
+
+// ErrLockTimeout é retornado quando o lock de migração não é obtido dentro do tempo limite.
+var ErrLockTimeout = drivers.ErrLockTimeout
+
+// LockFunc obtém o lock de migração identificado por name, aguardando até o cancelamento de ctx, e retorna a
+// função que libera o lock. Tem a mesma assinatura do método Lock dos drivers (veja WithLock).
+type LockFunc func(ctx context.Context, db *sql.DB, name string) (func() error, error)
+
+// DefaultLockTimeout é o tempo máximo padrão de espera pelo lock de migração (veja WithLockTimeout).
+const DefaultLockTimeout = 15 * time.Minute
+
+var (
+	lockTimeoutMu sync.RWMutex
+	lockTimeout   = DefaultLockTimeout // Tempo de espera das funções do pacote, configurado com SetLockTimeout
+)
+
+// SetLockTimeout configura o tempo máximo que as funções do pacote, como RunMigrations e Rollback, aguardam
+// pelo lock de migração. Não altera os Migrators, que usam o valor de WithLockTimeout.
+//
+// Deprecated: o valor é compartilhado por todo o processo; use um Migrator criado com WithLockTimeout.
+func SetLockTimeout(timeout time.Duration) {
+	lockTimeoutMu.Lock()
+	defer lockTimeoutMu.Unlock()
+	lockTimeout = timeout
+}
+
+// GetLockTimeout retorna o tempo máximo de espera pelo lock de migração das funções do pacote.
+//
+// Deprecated: use um Migrator criado com WithLockTimeout.
+func GetLockTimeout() time.Duration {
+	lockTimeoutMu.RLock()
+	defer lockTimeoutMu.RUnlock()
+	return lockTimeout
+}
+
+// acquireLock obtém o lock de migração da conexão, garantindo que apenas um processo execute migrações por vez.
+// O lock é identificado pelo nome da tabela de histórico, e é obtido com o driver, a não ser que outro tenha
+// sido configurado com WithLock. Aguarda no máximo o tempo de espera da conexão, ou até o cancelamento de ctx,
+// e retorna a função que libera o lock.
+func acquireLock(ctx context.Context, conn *connection) (func() error, error) {
+	ctx, cancel := context.WithTimeout(ctx, conn.lockTimeout)
+	defer cancel()
+
+	lock := conn.lock
+	if lock == nil {
+		lock = conn.driver.Lock
+	}
+	release, err := lock(ctx, conn.db, conn.table)
+	if err != nil {
+		if err == ErrLockTimeout {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Erro ao obter o lock de migração: %v", err)
+	}
+	return release, nil
+}
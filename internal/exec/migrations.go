@@ -0,0 +1,40 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// Sufixos dos arquivos de migração gerados. Arquivos terminados apenas em .sql são tratados como migrações "up".
+const (
+	upSuffix   = source.UpSuffix
+	downSuffix = source.DownSuffix
+)
+
+// readUp lê da origem o conteúdo que aplica a migração.
+func readUp(src source.Source, m source.Migration) ([]byte, error) {
+	return readMigration(src.OpenUp, m.Version, m.Name)
+}
+
+// readDown lê da origem o conteúdo que reverte a migração.
+func readDown(src source.Source, m source.Migration) ([]byte, error) {
+	return readMigration(src.OpenDown, m.Version, m.DownName)
+}
+
+// readMigration abre a versão com open e lê todo o conteúdo.
+func readMigration(open func(version int64) (io.ReadCloser, error), version int64, name string) ([]byte, error) {
+	r, err := open(version)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler arquivo de migração %s: %v", name, err)
+	}
+	defer r.Close()
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler arquivo de migração %s: %v", name, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,282 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drift"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// Logger recebe as mensagens de andamento das migrações, como "Executando migração: ...".
+// É implementado por *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// writerLogger escreve cada mensagem em uma linha de w.
+type writerLogger struct {
+	w io.Writer
+}
+
+// Printf implementa a interface Logger.
+func (l writerLogger) Printf(format string, v ...interface{}) {
+	fmt.Fprintf(l.w, format+"\n", v...)
+}
+
+// stdoutLogger é o Logger padrão, usado também pelas funções do pacote, como RunMigrations.
+var stdoutLogger Logger = writerLogger{os.Stdout}
+
+// Migrator executa as operações do sistema de migrações em um banco de dados, com as migrações de uma origem.
+// Toda a configuração fica no próprio Migrator, definida na criação com New e opções como WithDB e WithSource,
+// sem depender das configurações do pacote, como SetLockTimeout e RegisterMigration, de modo que vários
+// Migrators, com bancos, origens e tabelas de histórico diferentes, podem ser usados ao mesmo tempo no mesmo
+// processo.
+//
+// Exemplo de uso:
+//
+//	m, err := exec.New(exec.WithDB(db), exec.WithDir("migrations"), exec.WithLogger(log.Default()))
+//	if err != nil {
+//		return err
+//	}
+//	err = m.Up(ctx)
+type Migrator struct {
+	conn   *connection
+	src    source.Source
+	dir    string // Diretório onde as migrações são geradas, configurado com WithDir
+	logger Logger
+
+	goMigrations []GoMigration // Migrações Go configuradas com WithGoMigrations, validadas em New
+}
+
+// Option configura um Migrator criado com New.
+type Option func(*Migrator)
+
+// WithDB define a conexão com o banco de dados. Obrigatória.
+func WithDB(db *sql.DB) Option {
+	return func(m *Migrator) {
+		m.conn.db = db
+	}
+}
+
+// WithDriver define o Driver do banco de dados (veja drivers.Register). Por padrão, o driver é identificado
+// pelo tipo do driver database/sql da conexão (veja drivers.ForDB), o que não reconhece os drivers de outros
+// bancos de dados.
+func WithDriver(driver drivers.Driver) Option {
+	return func(m *Migrator) {
+		m.conn.driver = driver
+	}
+}
+
+// WithDialect substitui o dialeto do driver do banco de dados, usado na divisão dos comandos das migrações e
+// nos comandos da tabela de histórico. Útil com drivers database/sql não registrados (veja drivers.Register).
+func WithDialect(d dialect.Dialect) Option {
+	return func(m *Migrator) {
+		m.conn.sqlDialect = d
+	}
+}
+
+// WithSource define a origem das migrações (veja source.Source). Esta opção ou WithDir é obrigatória.
+func WithSource(src source.Source) Option {
+	return func(m *Migrator) {
+		m.src = src
+	}
+}
+
+// WithDir usa como origem os arquivos de migração do diretório dir, que também recebe as migrações criadas
+// com CreateMigration, GenerateMigration e GenerateDiffMigration.
+func WithDir(dir string) Option {
+	return func(m *Migrator) {
+		m.src, m.dir = source.Dir(dir), dir
+	}
+}
+
+// WithGoMigrations inclui migrações escritas em Go, executadas na ordem das versões junto com as da origem e
+// registradas no mesmo histórico, com o nome migration_<versão>.go. As migrações valem apenas para este
+// Migrator, inclusive no banco de comparação de DetectDrift.
+func WithGoMigrations(migrations ...GoMigration) Option {
+	return func(m *Migrator) {
+		m.goMigrations = append(m.goMigrations, migrations...)
+	}
+}
+
+// WithLogger define onde são escritas as mensagens de andamento. Por padrão, são escritas na saída padrão;
+// com logger nil, são descartadas.
+func WithLogger(logger Logger) Option {
+	return func(m *Migrator) {
+		if logger == nil {
+			logger = writerLogger{io.Discard}
+		}
+		m.logger = logger
+	}
+}
+
+// WithLock substitui o lock de migração do driver, como um lock já usado pela aplicação para coordenar as
+// suas instâncias. A função recebe o nome da tabela de histórico como nome do lock.
+func WithLock(lock LockFunc) Option {
+	return func(m *Migrator) {
+		m.conn.lock = lock
+	}
+}
+
+// WithLockTimeout define o tempo máximo de espera pelo lock de migração. O padrão é DefaultLockTimeout.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(m *Migrator) {
+		m.conn.lockTimeout = timeout
+	}
+}
+
+// WithTableName define o nome da tabela de histórico, que também identifica o lock de migração. O padrão é
+// HistoryTable. Permite manter históricos independentes no mesmo banco de dados, como o de cada módulo de
+// uma aplicação.
+func WithTableName(name string) Option {
+	return func(m *Migrator) {
+		m.conn.table = name
+	}
+}
+
+// tableName corresponde aos nomes aceitos em WithTableName, opcionalmente precedidos do schema.
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// New cria um Migrator com as opções informadas. A conexão com o banco de dados (WithDB) e a origem das
+// migrações (WithSource ou WithDir) são obrigatórias.
+// Retorna o Migrator e um possível erro, se houver.
+func New(opts ...Option) (*Migrator, error) {
+	m := &Migrator{
+		conn:   &connection{table: HistoryTable, lockTimeout: DefaultLockTimeout},
+		logger: stdoutLogger,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.conn.db == nil {
+		return nil, fmt.Errorf("Informe a conexão com o banco de dados com WithDB")
+	}
+	if m.src == nil {
+		return nil, fmt.Errorf("Informe a origem das migrações com WithSource ou WithDir")
+	}
+	if !tableName.MatchString(m.conn.table) {
+		return nil, fmt.Errorf("Nome de tabela de histórico inválido: %s", m.conn.table)
+	}
+	if m.conn.lockTimeout <= 0 {
+		return nil, fmt.Errorf("O tempo de espera pelo lock de migração deve ser maior que zero")
+	}
+	if m.conn.driver == nil {
+		m.conn.driver = drivers.ForDB(m.conn.db)
+	}
+	goMigrations, err := goMigrationSet(m.goMigrations)
+	if err != nil {
+		return nil, err
+	}
+	m.conn.goMigrations = goMigrations
+	return m, nil
+}
+
+// Up executa as migrações pendentes, assim como RunMigrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return runMigrations(ctx, m.conn, m.src, 0, 0, m.logger)
+}
+
+// UpSteps executa no máximo steps migrações pendentes, assim como RunMigrationSteps.
+func (m *Migrator) UpSteps(ctx context.Context, steps int) error {
+	return runMigrations(ctx, m.conn, m.src, steps, 0, m.logger)
+}
+
+// Down reverte as últimas steps migrações aplicadas, assim como Rollback.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("O número de migrações a reverter deve ser maior que zero")
+	}
+	return rollback(ctx, m.conn, m.src, steps, -1, m.logger)
+}
+
+// Goto leva o banco de dados exatamente à versão informada, assim como GotoFrom.
+func (m *Migrator) Goto(ctx context.Context, version int64) error {
+	return gotoVersion(ctx, m.conn, m.src, version, m.logger)
+}
+
+// Force registra o banco de dados como estando exatamente na versão informada, assim como Force.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return force(ctx, m.conn, m.src, version)
+}
+
+// Repair recalcula os checksums das migrações aplicadas, assim como Repair.
+func (m *Migrator) Repair(ctx context.Context) error {
+	return repair(ctx, m.conn, m.src, m.logger)
+}
+
+// Status retorna a situação de cada migração, assim como Status.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	return migrationStatus(ctx, m.conn, m.src)
+}
+
+// Plan retorna as migrações pendentes e os seus comandos, sem executá-los, assim como Plan.
+func (m *Migrator) Plan(ctx context.Context) ([]PlannedMigration, error) {
+	return planMigrations(ctx, m.conn, m.src, 0)
+}
+
+// PlanSteps retorna no máximo steps migrações pendentes, assim como PlanSteps.
+func (m *Migrator) PlanSteps(ctx context.Context, steps int) ([]PlannedMigration, error) {
+	return planMigrations(ctx, m.conn, m.src, steps)
+}
+
+// Version retorna a maior versão aplicada com sucesso, assim como CurrentVersion.
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	return currentVersion(ctx, m.conn)
+}
+
+// Introspect lê a estrutura das tabelas do banco de dados, sem a tabela de histórico e a tabela de lock do
+// Migrator, assim como Introspect.
+func (m *Migrator) Introspect(ctx context.Context) ([]config.Schema, error) {
+	return introspect(ctx, m.conn)
+}
+
+// DetectDrift compara a estrutura do banco de dados com a resultante das migrações, executadas no banco vazio
+// shadow com o mesmo driver e a mesma tabela de histórico, assim como DetectDrift.
+func (m *Migrator) DetectDrift(ctx context.Context, shadow *sql.DB) ([]drift.Difference, error) {
+	return detectDrift(ctx, m.conn, m.conn.with(shadow), m.src)
+}
+
+// CreateMigration cria um par de arquivos de migração vazios no diretório configurado com WithDir, assim
+// como CreateMigration.
+func (m *Migrator) CreateMigration(name string) (string, error) {
+	if err := m.requireDir(); err != nil {
+		return "", err
+	}
+	return CreateMigration(m.dir, name)
+}
+
+// GenerateMigration cria a migração das tabelas dos schemas no diretório configurado com WithDir, assim
+// como GenerateMigration.
+func (m *Migrator) GenerateMigration(schemas ...config.Schema) (string, error) {
+	if err := m.requireDir(); err != nil {
+		return "", err
+	}
+	return GenerateMigration(m.dir, schemas...)
+}
+
+// GenerateDiffMigration cria a migração com as diferenças entre as versões dos schemas no diretório
+// configurado com WithDir, assim como GenerateDiffMigration.
+func (m *Migrator) GenerateDiffMigration(previous []config.Schema, desired []config.Schema) (string, []string, error) {
+	if err := m.requireDir(); err != nil {
+		return "", nil, err
+	}
+	return GenerateDiffMigration(m.dir, previous, desired)
+}
+
+// requireDir verifica se o Migrator possui um diretório onde criar as migrações.
+func (m *Migrator) requireDir() error {
+	if m.dir == "" {
+		return fmt.Errorf("Informe o diretório de migrações com WithDir para criar migrações")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+	"github.com/LuisMarchio03/golang_migration_system/internal/statements"
+)
+
+// PlannedMigration descreve uma migração pendente, como seria executada por RunMigrations.
+type PlannedMigration struct {
+	Version       int64                  `json:"version"`       // Versão da migração
+	Name          string                 `json:"name"`          // Nome registrado na tabela de histórico
+	Go            bool                   `json:"go,omitempty"`  // Indica uma migração Go (veja WithGoMigrations), sem comandos SQL conhecidos
+	Transactional bool                   `json:"transactional"` // Indica se a migração seria executada em uma transação
+	Statements    []statements.Statement `json:"statements"`    // Comandos, na ordem de execução, depois da divisão do script
+}
+
+// Plan retorna as migrações pendentes da origem src, na ordem em que RunMigrationsFrom as executaria, com os
+// comandos de cada uma já divididos de acordo com o dialeto do banco de dados. Nada é executado, e a tabela
+// de histórico não é criada caso ainda não exista.
+// Assim como RunMigrations, retorna erro se o arquivo de uma migração já aplicada tiver sido alterado.
+func Plan(db *sql.DB, src source.Source) ([]PlannedMigration, error) {
+	return PlanSteps(db, src, 0)
+}
+
+// PlanSteps retorna no máximo steps migrações pendentes, assim como Plan. Com steps menor ou igual a zero,
+// retorna todas.
+func PlanSteps(db *sql.DB, src source.Source, steps int) ([]PlannedMigration, error) {
+	return planMigrations(context.Background(), newConnection(db), src, steps)
+}
+
+// planMigrations descreve no máximo steps migrações pendentes da origem src na conexão.
+func planMigrations(ctx context.Context, conn *connection, src source.Source, steps int) ([]PlannedMigration, error) {
+	// 1. Listar as migrações da origem e as migrações Go da conexão
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Carregar as versões já aplicadas e verificar os arquivos das migrações aplicadas
+	history, err := existingHistory(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDirty(history); err != nil {
+		return nil, err
+	}
+	if err := verifyChecksums(conn, src, migrations, history); err != nil {
+		return nil, err
+	}
+
+	// 3. Descrever as migrações pendentes
+	plan := []PlannedMigration{}
+	for _, m := range pendingMigrations(migrations, history, steps, 0) {
+		planned := PlannedMigration{Version: m.Version, Name: m.Name, Statements: []statements.Statement{}}
+		if _, ok := conn.goMigration(m.Version); ok {
+			planned.Go, planned.Transactional = true, true
+			plan = append(plan, planned)
+			continue
+		}
+
+		content, err := readUp(src, m)
+		if err != nil {
+			return nil, err
+		}
+		list, err := statements.Split(conn.dialect(), string(content))
+		if err != nil {
+			return nil, fmt.Errorf("Erro ao ler os comandos da migração %s: %v", m.Name, err)
+		}
+		if len(list) > 0 {
+			planned.Statements = list
+		}
+		planned.Transactional = transactional(conn.dialect(), string(content))
+		plan = append(plan, planned)
+	}
+	return plan, nil
+}
@@ -0,0 +1,162 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// Rollback reverte as últimas steps migrações aplicadas, da mais recente para a mais antiga,
+// executando o arquivo .down.sql de cada uma e removendo o seu registro da tabela de histórico.
+// Antes de reverter qualquer migração, verifica se todos os arquivos down necessários existem.
+// Assim como RunMigrations, mantém o lock de migração durante toda a execução.
+// Retorna um possível erro, se houver.
+func Rollback(db *sql.DB, migrationsDir string, steps int) error {
+	return RollbackFrom(db, source.Dir(migrationsDir), steps)
+}
+
+// RollbackFS reverte as últimas steps migrações aplicadas com os arquivos .down.sql da raiz de fsys,
+// assim como Rollback.
+func RollbackFS(db *sql.DB, fsys fs.FS, steps int) error {
+	return RollbackFrom(db, source.FS(fsys), steps)
+}
+
+// RollbackFrom reverte as últimas steps migrações aplicadas com as reversões da origem src, assim como Rollback.
+func RollbackFrom(db *sql.DB, src source.Source, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("O número de migrações a reverter deve ser maior que zero")
+	}
+	return rollback(context.Background(), newConnection(db), src, steps, -1, stdoutLogger)
+}
+
+// rollback reverte as migrações aplicadas com versão maior que target, da mais recente para a mais antiga,
+// limitadas a steps migrações. Valores negativos de target e steps menor ou igual a zero desativam o respectivo limite.
+// O andamento é escrito em logger.
+func rollback(ctx context.Context, conn *connection, src source.Source, steps int, target int64, logger Logger) error {
+	// 1. Listar as migrações da origem e as migrações Go da conexão
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return err
+	}
+
+	// 2. Obter o lock de migração, impedindo execuções simultâneas
+	release, err := acquireLock(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// 3. Carregar o histórico
+	if err := ensureHistoryTable(ctx, conn); err != nil {
+		return err
+	}
+	history, err := loadHistory(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	// 4. Verificar se o banco não está sujo, selecionar as últimas migrações aplicadas com sucesso e verificar
+	// se todas possuem arquivo down
+	if err := checkDirty(history); err != nil {
+		return err
+	}
+	reverted := revertibleMigrations(migrations, history, target)
+	if steps > 0 && steps < len(reverted) {
+		reverted = reverted[:steps]
+	}
+	if err := requireDown(reverted); err != nil {
+		return err
+	}
+
+	// 5. Reverter as migrações
+	for _, m := range reverted {
+		if err := revertMigration(ctx, conn, src, m, logger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// revertibleMigrations seleciona as migrações aplicadas com sucesso com versão maior que target, da mais recente
+// para a mais antiga. Versões registradas no histórico e ausentes da origem são retornadas sem arquivo down.
+func revertibleMigrations(migrations []source.Migration, history map[int64]AppliedMigration, target int64) []source.Migration {
+	byVersion := make(map[int64]source.Migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+
+	var reverted []source.Migration
+	for _, h := range history {
+		if !h.Success || h.Version <= target {
+			continue
+		}
+		m, ok := byVersion[h.Version]
+		if !ok {
+			m = source.Migration{Version: h.Version, Name: h.Name}
+		}
+		reverted = append(reverted, m)
+	}
+	sort.Slice(reverted, func(i, j int) bool {
+		return reverted[i].Version > reverted[j].Version
+	})
+	return reverted
+}
+
+// requireDown verifica, antes de reverter qualquer migração, se todas possuem arquivo down.
+func requireDown(migrations []source.Migration) error {
+	for _, m := range migrations {
+		if m.DownName == "" {
+			return fmt.Errorf("A migração %s não possui arquivo down para ser revertida", m.Name)
+		}
+	}
+	return nil
+}
+
+// revertMigration executa a reversão de uma migração e remove o seu registro da tabela de histórico,
+// na mesma transação quando possível. Assim como em applyMigration, o registro é marcado como sujo durante
+// a reversão.
+func revertMigration(ctx context.Context, conn *connection, src source.Source, m source.Migration, logger Logger) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	logger.Printf("Revertendo migração: %s", m.DownName)
+
+	g, isGo := conn.goMigration(m.Version)
+	var query []byte
+	if !isGo {
+		var err error
+		if query, err = readDown(src, m); err != nil {
+			return err
+		}
+	}
+	if err := markDirty(conn.db, conn, m.Version, true); err != nil {
+		return err
+	}
+
+	record := func(ex execer) error {
+		return deleteMigration(ex, conn, m.Version)
+	}
+	var err error
+	if isGo {
+		err = execGoMigration(ctx, conn, g.down, record)
+	} else {
+		err = execMigration(ctx, conn, string(query), record)
+	}
+	if err != nil {
+		// Com a transação desfeita, a migração continua aplicada e deixa de estar suja
+		if isGo || transactional(conn.dialect(), string(query)) {
+			if dirtyErr := markDirty(conn.db, conn, m.Version, false); dirtyErr != nil {
+				return fmt.Errorf("Erro ao reverter migração %s: %v (e ao registrar a falha no histórico: %v)", m.DownName, err, dirtyErr)
+			}
+		}
+		return fmt.Errorf("Erro ao reverter migração %s: %v", m.DownName, err)
+	}
+
+	logger.Printf("Migração revertida com sucesso.")
+	return nil
+}
@@ -1,48 +1,179 @@
 package exec
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
 )
 
-// RunMigrations executa todas as migrações encontradas no diretório migrationsDir no banco de dados especificado.
+// RunMigrations executa as migrações encontradas no diretório migrationsDir no banco de dados especificado.
+// As migrações executadas são registradas na tabela de histórico (schema_migrations), criada automaticamente,
+// e as versões já aplicadas com sucesso são ignoradas, permitindo repetir a execução com segurança.
+// Nos bancos com DDL transacional, cada migração e o seu registro no histórico são executados em uma
+// única transação (veja NoTransactionAnnotation).
+// Antes de executar, verifica se os arquivos das migrações já aplicadas não foram alterados, comparando
+// o seu SHA-256 com o registrado no histórico (veja ChecksumMismatchError e Repair).
+// Durante toda a execução é mantido um lock de migração, de modo que, com várias instâncias iniciando ao mesmo
+// tempo, apenas uma aplica as migrações e as demais aguardam (veja SetLockTimeout) e encontram o banco atualizado.
 // Retorna um possível erro, se houver.
 func RunMigrations(db *sql.DB, migrationsDir string) error {
-	// 1. Verificar se o diretório de migrações existe
-	if _, err := os.Stat(migrationsDir); os.IsNotExist(err) {
-		return fmt.Errorf("O diretório de migrações não existe")
+	return RunMigrationsFrom(db, source.Dir(migrationsDir))
+}
+
+// RunMigrationsFS executa as migrações encontradas na raiz de fsys, assim como RunMigrations. Permite embutir
+// as migrações no binário com go:embed; nesse caso, use fs.Sub para usar o diretório embutido como raiz:
+//
+//	//go:embed migrations/*.sql
+//	var embedded embed.FS
+//
+//	migrations, _ := fs.Sub(embedded, "migrations")
+//	err := exec.RunMigrationsFS(db, migrations)
+func RunMigrationsFS(db *sql.DB, fsys fs.FS) error {
+	return RunMigrationsFrom(db, source.FS(fsys))
+}
+
+// RunMigrationsFrom executa as migrações da origem src, como um pacote tar ou zip, um servidor HTTP ou uma lista
+// declarada no código (veja source.Source), assim como RunMigrations.
+func RunMigrationsFrom(db *sql.DB, src source.Source) error {
+	return runMigrations(context.Background(), newConnection(db), src, 0, 0, stdoutLogger)
+}
+
+// RunMigrationSteps executa no máximo steps migrações pendentes, na ordem das versões.
+// Com steps menor ou igual a zero, executa todas, assim como RunMigrations.
+func RunMigrationSteps(db *sql.DB, migrationsDir string, steps int) error {
+	return RunMigrationStepsFrom(db, source.Dir(migrationsDir), steps)
+}
+
+// RunMigrationStepsFS executa no máximo steps migrações pendentes da raiz de fsys, assim como RunMigrationSteps.
+func RunMigrationStepsFS(db *sql.DB, fsys fs.FS, steps int) error {
+	return RunMigrationStepsFrom(db, source.FS(fsys), steps)
+}
+
+// RunMigrationStepsFrom executa no máximo steps migrações pendentes da origem src, assim como RunMigrationSteps.
+func RunMigrationStepsFrom(db *sql.DB, src source.Source, steps int) error {
+	return runMigrations(context.Background(), newConnection(db), src, steps, 0, stdoutLogger)
+}
+
+// runMigrations executa as migrações pendentes de src, limitadas a steps migrações e às versões menores ou
+// iguais a target. Valores menores ou iguais a zero desativam o respectivo limite. O andamento é escrito em logger.
+func runMigrations(ctx context.Context, conn *connection, src source.Source, steps int, target int64, logger Logger) error {
+	// 1. Listar as migrações da origem e as migrações Go da conexão
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return err
+	}
+
+	// 2. Obter o lock de migração, impedindo execuções simultâneas
+	release, err := acquireLock(ctx, conn)
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	// 2. Listar arquivos de migração
-	files, err := ioutil.ReadDir(migrationsDir)
+	// 3. Garantir a tabela de histórico e carregar as versões já aplicadas
+	if err := ensureHistoryTable(ctx, conn); err != nil {
+		return err
+	}
+	history, err := loadHistory(ctx, conn)
 	if err != nil {
-		return fmt.Errorf("Erro ao listar arquivos de migração: %v", err)
+		return err
+	}
+
+	// 4. Verificar se o banco não está sujo e se os arquivos das migrações já aplicadas não foram alterados
+	if err := checkDirty(history); err != nil {
+		return err
+	}
+	if err := verifyChecksums(conn, src, migrations, history); err != nil {
+		return err
 	}
 
-	// 3. Executar migrações
-	for _, f := range files {
-		if !f.IsDir() && filepath.Ext(f.Name()) == ".sql" {
-			migrationPath := filepath.Join(migrationsDir, f.Name())
-			fmt.Println("Executando migração:", migrationPath)
+	// 5. Executar as migrações pendentes
+	for _, m := range migrations {
+		if applied, ok := history[m.Version]; ok && applied.Success {
+			logger.Printf("Migração já aplicada, ignorando: %s", m.Name)
+		}
+	}
+	for _, m := range pendingMigrations(migrations, history, steps, target) {
+		if err := applyMigration(ctx, conn, src, m, logger); err != nil {
+			return err
+		}
+	}
 
-			// Lê o conteúdo do arquivo de migração
-			query, err := ioutil.ReadFile(migrationPath)
-			if err != nil {
-				return fmt.Errorf("Erro ao ler arquivo de migração %s: %v", migrationPath, err)
-			}
+	return nil
+}
 
-			// Executa a migração
-			_, err = db.Exec(string(query))
-			if err != nil {
-				return fmt.Errorf("Erro ao executar migração %s: %v", migrationPath, err)
-			}
+// applyMigration executa uma migração e registra o resultado na tabela de histórico. Antes da execução, a
+// migração é registrada como suja, o que só é desfeito ao final com sucesso ou, em caso de falha, se a
+// transação da migração tiver sido desfeita por completo; a falha fica registrada no histórico.
+func applyMigration(ctx context.Context, conn *connection, src source.Source, m source.Migration, logger Logger) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	logger.Printf("Executando migração: %s", m.Name)
 
-			fmt.Println("Migração concluída com sucesso.")
+	// Lê o conteúdo do arquivo de migração
+	g, isGo := conn.goMigration(m.Version)
+	var query []byte
+	if !isGo {
+		var err error
+		if query, err = readUp(src, m); err != nil {
+			return err
 		}
 	}
 
+	// Registra a migração como suja, fora da transação da migração
+	result := AppliedMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now(), Dirty: true}
+	if !isGo {
+		result.Checksum = checksum(query)
+	}
+	if err := recordMigration(conn.db, conn, result); err != nil {
+		return err
+	}
+
+	// Executa a migração e registra o sucesso no histórico
+	record := func(ex execer) error {
+		result.ExecutionTime = time.Since(result.AppliedAt)
+		result.Success, result.Dirty = true, false
+		return recordMigration(ex, conn, result)
+	}
+	var err error
+	if isGo {
+		err = execGoMigration(ctx, conn, g.up, record)
+	} else {
+		err = execMigration(ctx, conn, string(query), record)
+	}
+	if err != nil {
+		// Sem transação, parte da migração pode ter sido aplicada: o registro continua sujo
+		result.ExecutionTime = time.Since(result.AppliedAt)
+		result.Success = false
+		result.Dirty = !isGo && !transactional(conn.dialect(), string(query))
+		if recordErr := recordMigration(conn.db, conn, result); recordErr != nil {
+			return fmt.Errorf("Erro ao executar migração %s: %v (e ao registrar a falha no histórico: %v)", m.Name, err, recordErr)
+		}
+		return fmt.Errorf("Erro ao executar migração %s: %v", m.Name, err)
+	}
+
+	logger.Printf("Migração concluída com sucesso.")
 	return nil
 }
+
+// pendingMigrations seleciona, na ordem das versões, as migrações ainda não aplicadas com sucesso, limitadas
+// a steps migrações e às versões menores ou iguais a target. Valores menores ou iguais a zero desativam o
+// respectivo limite.
+func pendingMigrations(migrations []source.Migration, history map[int64]AppliedMigration, steps int, target int64) []source.Migration {
+	var pending []source.Migration
+	for _, m := range migrations {
+		if applied, ok := history[m.Version]; ok && applied.Success {
+			continue
+		}
+		if (steps > 0 && len(pending) >= steps) || (target > 0 && m.Version > target) {
+			break
+		}
+		pending = append(pending, m)
+	}
+	return pending
+}
@@ -0,0 +1,144 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+)
+
+// MigrationState é a situação de uma migração, combinando a origem das migrações e a tabela de histórico.
+type MigrationState string
+
+// Situações de uma migração.
+const (
+	StateApplied          MigrationState = "applied"           // Aplicada com sucesso, com o arquivo inalterado
+	StatePending          MigrationState = "pending"           // Ainda não aplicada
+	StateMissingFile      MigrationState = "missing-file"      // Aplicada, mas sem o arquivo na origem das migrações
+	StateChecksumMismatch MigrationState = "checksum-mismatch" // Aplicada, mas o arquivo foi alterado depois (veja Repair)
+	StateDirty            MigrationState = "dirty"             // Interrompida ou com falha fora de uma transação (veja DirtyError)
+)
+
+// MigrationStatus descreve a situação de uma migração no banco de dados.
+type MigrationStatus struct {
+	Version       int64          // Versão da migração
+	Name          string         // Nome do arquivo de migração
+	State         MigrationState // Situação da migração
+	Applied       bool           // Indica se a migração foi aplicada com sucesso
+	AppliedAt     time.Time      // Momento da aplicação, quando registrada no histórico
+	ExecutionTime time.Duration  // Tempo gasto na execução, quando registrada no histórico
+}
+
+// Status combina os arquivos do diretório de migrações com a tabela de histórico e retorna a situação
+// de cada migração (veja MigrationState), ordenadas pela versão. Versões registradas no histórico cujo
+// arquivo não existe mais também são listadas. A tabela de histórico não é criada caso ainda não exista.
+// Retorna um possível erro, se houver.
+func Status(db *sql.DB, migrationsDir string) ([]MigrationStatus, error) {
+	return StatusFrom(db, source.Dir(migrationsDir))
+}
+
+// StatusFS combina os arquivos da raiz de fsys com a tabela de histórico, assim como Status.
+func StatusFS(db *sql.DB, fsys fs.FS) ([]MigrationStatus, error) {
+	return StatusFrom(db, source.FS(fsys))
+}
+
+// StatusFrom combina as migrações da origem src com a tabela de histórico, assim como Status.
+func StatusFrom(db *sql.DB, src source.Source) ([]MigrationStatus, error) {
+	return migrationStatus(context.Background(), newConnection(db), src)
+}
+
+// migrationStatus combina as migrações da origem src com a tabela de histórico da conexão.
+func migrationStatus(ctx context.Context, conn *connection, src source.Source) ([]MigrationStatus, error) {
+	migrations, err := listMigrations(conn, src)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := existingHistory(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var status []MigrationStatus
+	for _, m := range migrations {
+		h, ok := history[m.Version]
+		if !ok {
+			status = append(status, MigrationStatus{Version: m.Version, Name: m.Name, State: StatePending})
+			continue
+		}
+		delete(history, m.Version)
+
+		s := historyStatus(h)
+		s.Name = m.Name
+		if s.State == StateApplied && h.Checksum != "" {
+			if _, ok := conn.goMigration(m.Version); !ok {
+				content, err := readUp(src, m)
+				if err != nil {
+					return nil, err
+				}
+				if checksum(content) != h.Checksum {
+					s.State = StateChecksumMismatch
+				}
+			}
+		}
+		status = append(status, s)
+	}
+	for _, h := range history {
+		s := historyStatus(h)
+		if s.State != StateDirty {
+			s.State = StateMissingFile
+		}
+		status = append(status, s)
+	}
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Version < status[j].Version
+	})
+	return status, nil
+}
+
+// historyStatus retorna a situação registrada na tabela de histórico: suja, aplicada ou, depois de uma falha
+// desfeita pela transação, pendente.
+func historyStatus(h AppliedMigration) MigrationStatus {
+	s := MigrationStatus{
+		Version:       h.Version,
+		Name:          h.Name,
+		State:         StatePending,
+		Applied:       h.Success,
+		AppliedAt:     h.AppliedAt,
+		ExecutionTime: h.ExecutionTime,
+	}
+	switch {
+	case h.Dirty:
+		s.State = StateDirty
+	case h.Success:
+		s.State = StateApplied
+	}
+	return s
+}
+
+// CurrentVersion retorna a maior versão aplicada com sucesso no banco de dados, ou zero se nenhuma
+// migração foi aplicada.
+func CurrentVersion(db *sql.DB) (int64, error) {
+	return currentVersion(context.Background(), newConnection(db))
+}
+
+// currentVersion retorna a maior versão aplicada com sucesso registrada na tabela de histórico da conexão,
+// sem criá-la: sem a tabela, a versão é zero.
+func currentVersion(ctx context.Context, conn *connection) (int64, error) {
+	history, err := existingHistory(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for _, h := range history {
+		if h.Success && h.Version > version {
+			version = h.Version
+		}
+	}
+	return version, nil
+}
@@ -0,0 +1,98 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/statements"
+)
+
+// NoTransactionAnnotation é a anotação que, nos comentários do cabeçalho de um arquivo de migração,
+// desativa a transação para aquele arquivo. É necessária para comandos que não podem ser executados
+// dentro de uma transação, como CREATE INDEX CONCURRENTLY no PostgreSQL.
+//
+// Exemplo:
+//
+//	-- migrate:no-transaction
+//	CREATE INDEX CONCURRENTLY idx_users_email ON users (email);
+const NoTransactionAnnotation = "migrate:no-transaction"
+
+// execer é implementado por *sql.DB e *sql.Tx, permitindo gravar o histórico dentro ou fora de uma transação.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// hasAnnotation verifica se a anotação aparece nos comentários (--) do início do arquivo de migração.
+func hasAnnotation(content string, annotation string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "--") {
+			return false
+		}
+		if strings.TrimSpace(strings.TrimPrefix(line, "--")) == annotation {
+			return true
+		}
+	}
+	return false
+}
+
+// transactional indica se o conteúdo de uma migração é executado em uma transação: nos bancos com DDL
+// transacional, a não ser que o arquivo contenha a anotação NoTransactionAnnotation.
+func transactional(d dialect.Dialect, content string) bool {
+	return d.TransactionalDDL() && !hasAnnotation(content, NoTransactionAnnotation)
+}
+
+// execMigration executa o conteúdo de uma migração e, em seguida, a função record, que atualiza o histórico.
+// Nos bancos com DDL transacional (veja dialect.Dialect), ambos são executados em uma única transação, desfeita por completo em
+// caso de falha, a não ser que o arquivo contenha a anotação NoTransactionAnnotation.
+// O conteúdo é dividido nos seus comandos, de acordo com o dialeto (veja statements.Split), que são enviados
+// ao banco um por vez, pois nem todos os drivers aceitam vários comandos em uma única chamada.
+func execMigration(ctx context.Context, conn *connection, content string, record func(execer) error) error {
+	list, err := statements.Split(conn.dialect(), content)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		// Arquivos vazios, como os criados por CreateMigration, apenas atualizam o histórico
+		return record(conn.db)
+	}
+
+	if !transactional(conn.dialect(), content) {
+		if err := execStatements(ctx, conn.db, list); err != nil {
+			return err
+		}
+		return record(conn.db)
+	}
+
+	tx, err := conn.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := execStatements(ctx, tx, list); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// execStatements executa os comandos de um script, em ordem. O erro indica o número do comando que falhou
+// e a linha do script em que ele começa.
+func execStatements(ctx context.Context, ex execer, list []statements.Statement) error {
+	for i, statement := range list {
+		if _, err := ex.ExecContext(ctx, statement.SQL); err != nil {
+			return fmt.Errorf("comando %d (linha %d): %v", i+1, statement.Line, err)
+		}
+	}
+	return nil
+}
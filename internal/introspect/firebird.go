@@ -0,0 +1,139 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// firebirdTypes associa os códigos de RDB$FIELDS.RDB$FIELD_TYPE aos nomes usados no DDL.
+var firebirdTypes = map[int]string{
+	7:  "SMALLINT",
+	8:  "INTEGER",
+	10: "FLOAT",
+	12: "DATE",
+	13: "TIME",
+	14: "CHAR",
+	16: "BIGINT",
+	23: "BOOLEAN",
+	27: "DOUBLE PRECISION",
+	35: "TIMESTAMP",
+	37: "VARCHAR",
+}
+
+// Firebird lê as tabelas de usuário a partir das tabelas de sistema RDB$. Os nomes, que o Firebird guarda em
+// maiúsculas quando criados sem aspas, são retornados em minúsculas. Colunas identity, lidas de
+// RDB$IDENTITY_TYPE, exigem o Firebird 3 ou superior. Restrições CHECK não são lidas.
+func Firebird(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	b := newBuilder(dialect.Firebird, foldUpperCase)
+
+	// 1. Ler as colunas das tabelas, sem as visões e as tabelas do sistema
+	err := query(ctx, db, `SELECT rf.RDB$RELATION_NAME, rf.RDB$FIELD_NAME, f.RDB$FIELD_TYPE, COALESCE(f.RDB$FIELD_SUB_TYPE, 0),
+  COALESCE(f.RDB$CHARACTER_LENGTH, f.RDB$FIELD_LENGTH, 0), COALESCE(f.RDB$FIELD_PRECISION, 0), COALESCE(f.RDB$FIELD_SCALE, 0),
+  COALESCE(rf.RDB$NULL_FLAG, f.RDB$NULL_FLAG, 0), CAST(COALESCE(rf.RDB$DEFAULT_SOURCE, f.RDB$DEFAULT_SOURCE, '') AS VARCHAR(8191)),
+  CASE WHEN rf.RDB$IDENTITY_TYPE IS NULL THEN 0 ELSE 1 END
+FROM RDB$RELATION_FIELDS rf
+JOIN RDB$FIELDS f ON f.RDB$FIELD_NAME = rf.RDB$FIELD_SOURCE
+JOIN RDB$RELATIONS r ON r.RDB$RELATION_NAME = rf.RDB$RELATION_NAME
+WHERE COALESCE(r.RDB$SYSTEM_FLAG, 0) = 0 AND r.RDB$VIEW_BLR IS NULL
+ORDER BY rf.RDB$RELATION_NAME, rf.RDB$FIELD_POSITION`, func(rows *sql.Rows) error {
+		var table, column, defaultValue string
+		var fieldType, subType, length, precision, scale, notNull, identity int
+		if err := rows.Scan(&table, &column, &fieldType, &subType, &length, &precision, &scale, &notNull, &defaultValue, &identity); err != nil {
+			return err
+		}
+
+		// RDB$DEFAULT_SOURCE guarda a cláusula inteira, como "DEFAULT 0"
+		defaultValue = strings.TrimSpace(defaultValue)
+		if strings.HasPrefix(strings.ToUpper(defaultValue), "DEFAULT") {
+			defaultValue = defaultValue[len("DEFAULT"):]
+		}
+		b.column(table, column, firebirdType(fieldType, subType, length, precision, scale), notNull == 0, defaultValue, identity == 1)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ler a chave primária, as restrições UNIQUE e as chaves estrangeiras, com uma linha por coluna; a coluna
+	// referenciada é a de mesma posição no índice da chave referenciada
+	err = query(ctx, db, `SELECT rc.RDB$RELATION_NAME, rc.RDB$CONSTRAINT_NAME, rc.RDB$CONSTRAINT_TYPE, s.RDB$FIELD_NAME,
+  COALESCE(uq.RDB$RELATION_NAME, ''), COALESCE(us.RDB$FIELD_NAME, ''), COALESCE(ref.RDB$DELETE_RULE, ''), COALESCE(ref.RDB$UPDATE_RULE, '')
+FROM RDB$RELATION_CONSTRAINTS rc
+JOIN RDB$INDEX_SEGMENTS s ON s.RDB$INDEX_NAME = rc.RDB$INDEX_NAME
+LEFT JOIN RDB$REF_CONSTRAINTS ref ON ref.RDB$CONSTRAINT_NAME = rc.RDB$CONSTRAINT_NAME
+LEFT JOIN RDB$RELATION_CONSTRAINTS uq ON uq.RDB$CONSTRAINT_NAME = ref.RDB$CONST_NAME_UQ
+LEFT JOIN RDB$INDEX_SEGMENTS us ON us.RDB$INDEX_NAME = uq.RDB$INDEX_NAME AND us.RDB$FIELD_POSITION = s.RDB$FIELD_POSITION
+WHERE rc.RDB$CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+ORDER BY rc.RDB$RELATION_NAME, rc.RDB$CONSTRAINT_NAME, s.RDB$FIELD_POSITION`, func(rows *sql.Rows) error {
+		var table, name, kind, column, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&table, &name, &kind, &column, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		switch strings.TrimSpace(kind) {
+		case "PRIMARY KEY":
+			b.primaryKeyColumn(table, column)
+		case "UNIQUE":
+			b.uniqueColumn(table, name, name, column)
+		case "FOREIGN KEY":
+			b.foreignKeyColumn(table, name, name, column, refTable, refColumn, onDelete, onUpdate)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Ler os índices que não pertencem a restrições
+	err = query(ctx, db, `SELECT i.RDB$RELATION_NAME, i.RDB$INDEX_NAME, COALESCE(i.RDB$UNIQUE_FLAG, 0), s.RDB$FIELD_NAME
+FROM RDB$INDICES i
+JOIN RDB$INDEX_SEGMENTS s ON s.RDB$INDEX_NAME = i.RDB$INDEX_NAME
+WHERE COALESCE(i.RDB$SYSTEM_FLAG, 0) = 0
+  AND NOT EXISTS (SELECT 1 FROM RDB$RELATION_CONSTRAINTS rc WHERE rc.RDB$INDEX_NAME = i.RDB$INDEX_NAME)
+ORDER BY i.RDB$RELATION_NAME, i.RDB$INDEX_NAME, s.RDB$FIELD_POSITION`, func(rows *sql.Rows) error {
+		var table, index, column string
+		var unique int
+		if err := rows.Scan(&table, &index, &unique, &column); err != nil {
+			return err
+		}
+		b.indexColumn(table, index, unique == 1, column)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.schemas(), nil
+}
+
+// firebirdType monta o tipo da coluna, como usado no DDL, a partir dos campos de RDB$FIELDS. Os tipos inteiros
+// com escala negativa são colunas NUMERIC ou DECIMAL.
+func firebirdType(fieldType int, subType int, length int, precision int, scale int) string {
+	switch {
+	case (fieldType == 7 || fieldType == 8 || fieldType == 16) && scale < 0:
+		return dialect.SizedType("DECIMAL", precision, -scale)
+	case fieldType == 14 || fieldType == 37:
+		return dialect.SizedType(firebirdTypes[fieldType], length, 0)
+	case fieldType == 261 && subType == 1:
+		return "BLOB SUB_TYPE TEXT"
+	case fieldType == 261:
+		return "BLOB SUB_TYPE BINARY"
+	}
+	if name, ok := firebirdTypes[fieldType]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+// foldUpperCase remove os espaços com que o Firebird completa os nomes e converte para minúsculas os nomes
+// inteiramente em maiúsculas, que são os criados sem aspas.
+func foldUpperCase(name string) string {
+	name = strings.TrimSpace(name)
+	if name == strings.ToUpper(name) {
+		return strings.ToLower(name)
+	}
+	return name
+}
@@ -0,0 +1,244 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// candidates são os tipos lógicos testados, nesta ordem, ao converter o tipo de uma coluna do banco.
+var candidates = []config.DataType{
+	config.Integer, config.BigInt, config.SmallInt, config.Text, config.String, config.Decimal, config.Float,
+	config.Boolean, config.Timestamp, config.Date, config.Binary, config.UUID, config.JSON,
+}
+
+// typeArguments localiza os argumentos de um tipo, como "50" em VARCHAR(50) e "10,2" em DECIMAL(10,2).
+var typeArguments = regexp.MustCompile(`\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+
+// typeSpaces localiza os espaços dentro dos parênteses dos argumentos, como em DECIMAL(10, 2).
+var typeSpaces = regexp.MustCompile(`\s*([(,])\s*|\s+(\))`)
+
+// logicalColumn converte o tipo de uma coluna do banco no tipo lógico que o dialeto gera exatamente com o mesmo
+// tipo, de modo que a migração gerada a partir do schema recrie a coluna como ela está. Quando nenhum tipo lógico
+// corresponde, o tipo do banco é mantido em DataType, que é gerado como foi escrito.
+func logicalColumn(d dialect.Dialect, engineType string) config.Column {
+	engineType = strings.Join(strings.Fields(strings.ToUpper(engineType)), " ")
+	engineType = typeSpaces.ReplaceAllString(engineType, "$1$2")
+
+	var size, scale int
+	if match := typeArguments.FindStringSubmatch(engineType); match != nil {
+		size, _ = strconv.Atoi(match[1])
+		scale, _ = strconv.Atoi(match[2])
+	}
+
+	for _, dataType := range candidates {
+		column := config.Column{DataType: dataType}
+		switch dataType {
+		case config.String, config.Binary:
+			column.Length = size
+		case config.Decimal:
+			column.Precision, column.Scale = size, scale
+		}
+		if strings.EqualFold(d.ColumnType(column), engineType) {
+			return column
+		}
+	}
+	return config.Column{DataType: config.DataType(engineType)}
+}
+
+// normalizeAction converte a ação ON DELETE ou ON UPDATE lida do catálogo para a forma usada em config.ForeignKey,
+// com NO ACTION, o comportamento padrão, representado por "".
+func normalizeAction(action string) string {
+	action = strings.ToUpper(strings.TrimSpace(strings.ReplaceAll(action, "_", " ")))
+	if action == "NO ACTION" {
+		return ""
+	}
+	return action
+}
+
+// builder monta os schemas a partir das linhas lidas do catálogo.
+type builder struct {
+	d      dialect.Dialect
+	fold   func(string) string
+	tables map[string]*table
+}
+
+// table acumula a estrutura lida de uma tabela.
+type table struct {
+	schema      config.Schema
+	primaryKey  []string
+	uniques     map[string]*config.UniqueConstraint
+	uniqueOrder []string
+	indexes     map[string]*config.Index
+	indexOrder  []string
+	foreignKeys map[string]*config.ForeignKey
+	fkOrder     []string
+}
+
+// newBuilder cria um builder para o dialeto. fold, quando informado, é aplicado a todos os nomes lidos.
+func newBuilder(d dialect.Dialect, fold func(string) string) *builder {
+	if fold == nil {
+		fold = strings.TrimSpace
+	}
+	return &builder{d: d, fold: fold, tables: make(map[string]*table)}
+}
+
+// column acrescenta uma coluna à tabela, criando a tabela na primeira coluna.
+func (b *builder) column(tableName string, name string, engineType string, nullable bool, defaultValue string, autoIncrement bool) {
+	tableName = b.fold(tableName)
+	t, ok := b.tables[tableName]
+	if !ok {
+		t = &table{
+			schema:      config.Schema{DbType: b.d.Name(), TableName: tableName},
+			uniques:     make(map[string]*config.UniqueConstraint),
+			indexes:     make(map[string]*config.Index),
+			foreignKeys: make(map[string]*config.ForeignKey),
+		}
+		b.tables[tableName] = t
+	}
+
+	column := logicalColumn(b.d, engineType)
+	column.Name = b.fold(name)
+	column.Nullable = nullable
+	column.Default = strings.TrimSpace(defaultValue)
+	column.AutoIncrement = autoIncrement
+	t.schema.Columns = append(t.schema.Columns, column)
+}
+
+// table retorna a tabela já lida com o nome informado, ou nil para tabelas ignoradas, como as visões e as
+// tabelas do sistema.
+func (b *builder) table(name string) *table {
+	return b.tables[b.fold(name)]
+}
+
+// primaryKeyColumn acrescenta uma coluna à chave primária da tabela.
+func (b *builder) primaryKeyColumn(tableName string, column string) {
+	if t := b.table(tableName); t != nil {
+		t.primaryKey = append(t.primaryKey, b.fold(column))
+	}
+}
+
+// uniqueColumn acrescenta uma coluna à restrição UNIQUE identificada por key.
+func (b *builder) uniqueColumn(tableName string, key string, name string, column string) {
+	t := b.table(tableName)
+	if t == nil {
+		return
+	}
+	unique, ok := t.uniques[key]
+	if !ok {
+		unique = &config.UniqueConstraint{Name: b.fold(name)}
+		t.uniques[key] = unique
+		t.uniqueOrder = append(t.uniqueOrder, key)
+	}
+	unique.Columns = append(unique.Columns, b.fold(column))
+}
+
+// indexColumn acrescenta uma coluna ao índice.
+func (b *builder) indexColumn(tableName string, name string, unique bool, column string) {
+	t := b.table(tableName)
+	if t == nil {
+		return
+	}
+	index, ok := t.indexes[name]
+	if !ok {
+		index = &config.Index{Name: b.fold(name), Unique: unique}
+		t.indexes[name] = index
+		t.indexOrder = append(t.indexOrder, name)
+	}
+	index.Columns = append(index.Columns, b.fold(column))
+}
+
+// foreignKeyColumn acrescenta um par de colunas à chave estrangeira identificada por key.
+func (b *builder) foreignKeyColumn(tableName string, key string, name string, column string, refTable string, refColumn string, onDelete string, onUpdate string) {
+	t := b.table(tableName)
+	if t == nil {
+		return
+	}
+	fk, ok := t.foreignKeys[key]
+	if !ok {
+		fk = &config.ForeignKey{Name: b.fold(name), RefTable: b.fold(refTable), OnDelete: normalizeAction(onDelete), OnUpdate: normalizeAction(onUpdate)}
+		t.foreignKeys[key] = fk
+		t.fkOrder = append(t.fkOrder, key)
+	}
+	fk.Columns = append(fk.Columns, b.fold(column))
+	if refColumn != "" {
+		// Sem a coluna referenciada, a chave referencia a chave primária da outra tabela
+		fk.RefColumns = append(fk.RefColumns, b.fold(refColumn))
+	}
+}
+
+// schemas retorna os schemas lidos, em ordem alfabética das tabelas. Uma chave primária de uma coluna é marcada
+// em Column.PrimaryKey; como Schema não descreve chaves primárias compostas, elas são retornadas como restrição
+// UNIQUE sobre colunas NOT NULL, que tem o mesmo efeito. Restrições UNIQUE de uma coluna são marcadas em
+// Column.Unique.
+func (b *builder) schemas() []config.Schema {
+	names := make([]string, 0, len(b.tables))
+	for name := range b.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schemas := make([]config.Schema, 0, len(names))
+	for _, name := range names {
+		t := b.tables[name]
+		schema := t.schema
+
+		columnIndex := make(map[string]int, len(schema.Columns))
+		for i, column := range schema.Columns {
+			columnIndex[column.Name] = i
+		}
+
+		switch {
+		case len(t.primaryKey) == 1:
+			column := &schema.Columns[columnIndex[t.primaryKey[0]]]
+			column.PrimaryKey = true
+			column.Nullable = false
+		case len(t.primaryKey) > 1:
+			for _, name := range t.primaryKey {
+				schema.Columns[columnIndex[name]].Nullable = false
+			}
+			schema.UniqueConstraints = append(schema.UniqueConstraints, config.UniqueConstraint{Columns: t.primaryKey})
+		}
+
+		for _, key := range t.uniqueOrder {
+			unique := t.uniques[key]
+			if len(unique.Columns) == 1 {
+				schema.Columns[columnIndex[unique.Columns[0]]].Unique = true
+				continue
+			}
+			schema.UniqueConstraints = append(schema.UniqueConstraints, *unique)
+		}
+		for _, key := range t.indexOrder {
+			schema.Indexes = append(schema.Indexes, *t.indexes[key])
+		}
+		for _, key := range t.fkOrder {
+			schema.ForeignKeys = append(schema.ForeignKeys, *t.foreignKeys[key])
+		}
+
+		schemas = append(schemas, schema)
+	}
+	return schemas
+}
+
+// query executa a consulta e chama scan para cada linha do resultado, que é lido por completo antes do retorno.
+func query(ctx context.Context, db *sql.DB, sqlQuery string, scan func(rows *sql.Rows) error, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return fmt.Errorf("Erro ao consultar o catálogo do banco de dados: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("Erro ao ler o catálogo do banco de dados: %v", err)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,76 @@
+package introspect
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalColumn(t *testing.T) {
+	// Tipos gerados pelo dialeto voltam ao tipo lógico, com o tamanho, a precisão e a escala
+	assert.Equal(t, config.Column{DataType: config.String, Length: 50}, logicalColumn(dialect.PostgreSQL, "VARCHAR(50)"))
+	assert.Equal(t, config.Column{DataType: config.Decimal, Precision: 10, Scale: 2}, logicalColumn(dialect.MySQL, "decimal(10, 2)"))
+	assert.Equal(t, config.Column{DataType: config.Boolean}, logicalColumn(dialect.MySQL, "TINYINT(1)"))
+	assert.Equal(t, config.Column{DataType: config.Text}, logicalColumn(dialect.SQLServer, "NVARCHAR(MAX)"))
+
+	assert.Equal(t, config.Column{DataType: "TIMESTAMP(6) WITH TIME ZONE"}, logicalColumn(dialect.Oracle, "timestamp( 6 ) with time zone"))
+
+	// Os demais tipos são mantidos como foram lidos
+	assert.Equal(t, config.Column{DataType: "MEDIUMINT UNSIGNED"}, logicalColumn(dialect.MySQL, "mediumint  unsigned"))
+}
+
+func TestEngineTypes(t *testing.T) {
+	// MySQL: a largura de exibição dos inteiros é descartada, exceto em TINYINT(1)
+	for engineType, expected := range map[string]string{"INT(11)": "INT", "BIGINT(20) UNSIGNED": "BIGINT UNSIGNED", "TINYINT(1)": "TINYINT(1)", "TINYINT(4)": "TINYINT", "VARCHAR(20)": "VARCHAR(20)"} {
+		assert.Equal(t, expected, mysqlDisplayWidth.ReplaceAllString(engineType, "$1$2"), engineType)
+	}
+	assert.Equal(t, "'ativo'", mysqlDefault(sql.NullString{String: "ativo", Valid: true}, ""))
+	assert.Equal(t, "CURRENT_TIMESTAMP", mysqlDefault(sql.NullString{String: "CURRENT_TIMESTAMP", Valid: true}, "DEFAULT_GENERATED"))
+
+	// PostgreSQL
+	assert.Equal(t, "VARCHAR(80)", postgreSQLType("character varying", "varchar", 80, 0, 0))
+	assert.Equal(t, "DECIMAL(12,4)", postgreSQLType("numeric", "numeric", 0, 12, 4))
+	assert.Equal(t, "TIMESTAMPTZ", postgreSQLType("timestamp with time zone", "timestamptz", 0, 0, 0))
+	assert.Equal(t, "_INT4", postgreSQLType("ARRAY", "_int4", 0, 0, 0))
+
+	// SQL Server: o tamanho dos tipos Unicode é dado em bytes
+	assert.Equal(t, "NVARCHAR(100)", sqlServerType("nvarchar", 200, 0, 0))
+	assert.Equal(t, "VARBINARY(MAX)", sqlServerType("varbinary", -1, 0, 0))
+	assert.Equal(t, "0", unwrapParentheses("((0))"))
+	assert.Equal(t, "getdate()", unwrapParentheses("(getdate())"))
+	assert.Equal(t, "(1) + (2)", unwrapParentheses("((1) + (2))"))
+
+	// Firebird: inteiros com escala negativa são DECIMAL
+	assert.Equal(t, "DECIMAL(10,2)", firebirdType(16, 2, 8, 10, -2))
+	assert.Equal(t, "BLOB SUB_TYPE TEXT", firebirdType(261, 1, 8, 0, 0))
+	assert.Equal(t, "users", foldUpperCase("USERS   "))
+	assert.Equal(t, "MixedCase", foldUpperCase("MixedCase"))
+}
+
+func TestBuilderSchemas(t *testing.T) {
+	b := newBuilder(dialect.PostgreSQL, nil)
+	b.column("order_items", "order_id", "INTEGER", false, "", false)
+	b.column("order_items", "product_id", "INTEGER", false, "", false)
+	b.column("order_items", "sku", "VARCHAR(20)", true, "", false)
+	b.primaryKeyColumn("order_items", "order_id")
+	b.primaryKeyColumn("order_items", "product_id")
+	b.uniqueColumn("order_items", "uq", "uq_order_items_sku", "sku")
+	b.foreignKeyColumn("order_items", "fk", "fk_order", "order_id", "orders", "id", "NO_ACTION", "cascade")
+
+	// Colunas de tabelas ignoradas, como as visões, não criam tabelas
+	b.primaryKeyColumn("active_orders", "id")
+
+	schemas := b.schemas()
+	if assert.Len(t, schemas, 1) {
+		schema := schemas[0]
+		// A chave primária composta é retornada como restrição UNIQUE
+		assert.Equal(t, []config.UniqueConstraint{{Columns: []string{"order_id", "product_id"}}}, schema.UniqueConstraints)
+		assert.True(t, schema.Columns[2].Unique)
+		assert.Equal(t, []config.ForeignKey{{Name: "fk_order", Columns: []string{"order_id"}, RefTable: "orders", RefColumns: []string{"id"}, OnUpdate: "CASCADE"}}, schema.ForeignKeys)
+		assert.True(t, strings.HasPrefix(dialect.PostgreSQL.CreateTable(schema), "CREATE TABLE IF NOT EXISTS order_items"))
+	}
+}
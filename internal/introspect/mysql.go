@@ -0,0 +1,105 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// mysqlDisplayWidth localiza a largura de exibição dos tipos inteiros (INT(11)), registrada pelo MySQL 5.7,
+// que não altera o tipo. TINYINT(1) é mantido, pois é o tipo usado para BOOLEAN.
+var mysqlDisplayWidth = regexp.MustCompile(`^(SMALLINT|MEDIUMINT|INT|BIGINT)\(\d+\)|^(TINYINT)\((?:[02-9]|\d{2,})\)`)
+
+// MySQL lê as tabelas do banco de dados atual (DATABASE()) a partir do information_schema. Restrições CHECK
+// não são lidas.
+func MySQL(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	b := newBuilder(dialect.MySQL, nil)
+
+	// 1. Ler as colunas das tabelas, sem as visões
+	err := query(ctx, db, `SELECT c.TABLE_NAME, c.COLUMN_NAME, c.COLUMN_TYPE, c.IS_NULLABLE, c.COLUMN_DEFAULT, c.EXTRA
+FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE c.TABLE_SCHEMA = DATABASE() AND t.TABLE_TYPE = 'BASE TABLE'
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`, func(rows *sql.Rows) error {
+		var table, column, columnType, nullable, extra string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&table, &column, &columnType, &nullable, &defaultValue, &extra); err != nil {
+			return err
+		}
+		columnType = mysqlDisplayWidth.ReplaceAllString(strings.ToUpper(columnType), "$1$2")
+		b.column(table, column, columnType, nullable == "YES", mysqlDefault(defaultValue, extra), strings.Contains(strings.ToLower(extra), "auto_increment"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ler a chave primária, as restrições UNIQUE e os índices. Os índices criados pelo MySQL para as chaves
+	// estrangeiras, com o mesmo nome da chave, são ignorados.
+	err = query(ctx, db, `SELECT s.TABLE_NAME, s.INDEX_NAME, s.NON_UNIQUE, s.COLUMN_NAME, COALESCE(tc.CONSTRAINT_TYPE, '')
+FROM information_schema.STATISTICS s
+LEFT JOIN information_schema.TABLE_CONSTRAINTS tc
+  ON tc.TABLE_SCHEMA = s.TABLE_SCHEMA AND tc.TABLE_NAME = s.TABLE_NAME AND tc.CONSTRAINT_NAME = s.INDEX_NAME
+WHERE s.TABLE_SCHEMA = DATABASE()
+ORDER BY s.TABLE_NAME, s.INDEX_NAME, s.SEQ_IN_INDEX`, func(rows *sql.Rows) error {
+		var table, index, column, constraintType string
+		var nonUnique int
+		if err := rows.Scan(&table, &index, &nonUnique, &column, &constraintType); err != nil {
+			return err
+		}
+		switch constraintType {
+		case "PRIMARY KEY":
+			b.primaryKeyColumn(table, column)
+		case "UNIQUE":
+			b.uniqueColumn(table, index, index, column)
+		case "":
+			b.indexColumn(table, index, nonUnique == 0, column)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Ler as chaves estrangeiras
+	err = query(ctx, db, `SELECT k.TABLE_NAME, k.CONSTRAINT_NAME, k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME,
+  r.DELETE_RULE, r.UPDATE_RULE
+FROM information_schema.KEY_COLUMN_USAGE k
+JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+  ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA AND r.TABLE_NAME = k.TABLE_NAME AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+WHERE k.TABLE_SCHEMA = DATABASE() AND k.REFERENCED_TABLE_NAME IS NOT NULL
+ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`, func(rows *sql.Rows) error {
+		var table, name, column, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&table, &name, &column, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		b.foreignKeyColumn(table, name, name, column, refTable, refColumn, onDelete, onUpdate)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.schemas(), nil
+}
+
+// mysqlDefault converte o valor padrão lido do information_schema em uma expressão SQL. O MySQL registra os
+// literais de texto sem aspas; as expressões, como CURRENT_TIMESTAMP, são marcadas com DEFAULT_GENERATED
+// no MySQL 8.
+func mysqlDefault(value sql.NullString, extra string) string {
+	if !value.Valid {
+		return ""
+	}
+	if _, err := strconv.ParseFloat(value.String, 64); err == nil {
+		return value.String
+	}
+	if strings.Contains(strings.ToUpper(extra), "DEFAULT_GENERATED") || strings.HasPrefix(strings.ToUpper(value.String), "CURRENT_TIMESTAMP") {
+		return value.String
+	}
+	return "'" + strings.ReplaceAll(value.String, "'", "''") + "'"
+}
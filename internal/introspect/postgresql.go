@@ -0,0 +1,131 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// postgreSQLTypes associa os nomes de tipo do information_schema aos nomes usados no DDL.
+var postgreSQLTypes = map[string]string{
+	"timestamp without time zone": "TIMESTAMP",
+	"timestamp with time zone":    "TIMESTAMPTZ",
+	"time without time zone":      "TIME",
+	"time with time zone":         "TIMETZ",
+}
+
+// PostgreSQL lê as tabelas do schema atual (current_schema()) a partir do information_schema, para as colunas,
+// e do pg_catalog, para as restrições e os índices. Colunas serial e identity são retornadas como auto incremento.
+// Restrições CHECK não são lidas.
+func PostgreSQL(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	b := newBuilder(dialect.PostgreSQL, nil)
+
+	// 1. Ler as colunas das tabelas, sem as visões
+	err := query(ctx, db, `SELECT c.table_name, c.column_name, c.data_type, c.udt_name, c.character_maximum_length,
+  c.numeric_precision, c.numeric_scale, c.is_nullable, c.column_default, c.is_identity
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = current_schema() AND t.table_type = 'BASE TABLE'
+ORDER BY c.table_name, c.ordinal_position`, func(rows *sql.Rows) error {
+		var table, column, dataType, udtName, nullable, identity string
+		var length, precision, scale sql.NullInt64
+		var defaultValue sql.NullString
+		if err := rows.Scan(&table, &column, &dataType, &udtName, &length, &precision, &scale, &nullable, &defaultValue, &identity); err != nil {
+			return err
+		}
+
+		// Colunas serial usam como valor padrão a próxima posição de uma sequência
+		autoIncrement := identity == "YES" || strings.HasPrefix(defaultValue.String, "nextval(")
+		if autoIncrement {
+			defaultValue.String = ""
+		}
+		columnType := postgreSQLType(dataType, udtName, int(length.Int64), int(precision.Int64), int(scale.Int64))
+		b.column(table, column, columnType, nullable == "YES", defaultValue.String, autoIncrement)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ler a chave primária, as restrições UNIQUE e as chaves estrangeiras, com uma linha por coluna
+	err = query(ctx, db, `SELECT t.relname, c.conname, c.contype, a.attname, COALESCE(rt.relname, ''), COALESCE(ra.attname, ''),
+  c.confdeltype, c.confupdtype
+FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord)
+JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+LEFT JOIN pg_class rt ON rt.oid = c.confrelid
+LEFT JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = c.confkey[k.ord]
+WHERE n.nspname = current_schema() AND c.contype IN ('p', 'u', 'f')
+ORDER BY t.relname, c.conname, k.ord`, func(rows *sql.Rows) error {
+		var table, name, kind, column, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&table, &name, &kind, &column, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		switch kind {
+		case "p":
+			b.primaryKeyColumn(table, column)
+		case "u":
+			b.uniqueColumn(table, name, name, column)
+		case "f":
+			b.foreignKeyColumn(table, name, name, column, refTable, refColumn, postgreSQLAction(onDelete), postgreSQLAction(onUpdate))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Ler os índices que não pertencem a restrições
+	err = query(ctx, db, `SELECT t.relname, i.relname, ix.indisunique, a.attname
+FROM pg_index ix
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord)
+JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE n.nspname = current_schema()
+  AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = ix.indexrelid)
+ORDER BY t.relname, i.relname, k.ord`, func(rows *sql.Rows) error {
+		var table, index, column string
+		var unique bool
+		if err := rows.Scan(&table, &index, &unique, &column); err != nil {
+			return err
+		}
+		b.indexColumn(table, index, unique, column)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.schemas(), nil
+}
+
+// postgreSQLType monta o tipo da coluna, como usado no DDL, a partir das colunas do information_schema.
+func postgreSQLType(dataType string, udtName string, length int, precision int, scale int) string {
+	switch dataType {
+	case "character varying":
+		return dialect.SizedType("VARCHAR", length, 0)
+	case "character":
+		return dialect.SizedType("CHAR", length, 0)
+	case "numeric":
+		// DECIMAL e NUMERIC são sinônimos no PostgreSQL
+		return dialect.SizedType("DECIMAL", precision, scale)
+	case "ARRAY", "USER-DEFINED":
+		return strings.ToUpper(udtName)
+	}
+	if name, ok := postgreSQLTypes[dataType]; ok {
+		return name
+	}
+	return strings.ToUpper(dataType)
+}
+
+// postgreSQLAction converte o código de ação das chaves estrangeiras do pg_constraint.
+func postgreSQLAction(code string) string {
+	return map[string]string{"a": "NO ACTION", "r": "RESTRICT", "c": "CASCADE", "n": "SET NULL", "d": "SET DEFAULT"}[code]
+}
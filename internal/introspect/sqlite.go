@@ -0,0 +1,140 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// sqliteAutoIncrement localiza a palavra-chave AUTOINCREMENT no comando de criação de uma tabela.
+var sqliteAutoIncrement = regexp.MustCompile(`(?i)\bAUTOINCREMENT\b`)
+
+// SQLite lê as tabelas de um banco SQLite a partir de sqlite_master e das instruções PRAGMA table_info,
+// index_list, index_info e foreign_key_list. Os nomes das restrições UNIQUE e das chaves estrangeiras não são
+// registrados pelo SQLite, por isso são retornados vazios (veja config.Schema.UniqueName e ForeignKeyName).
+// Restrições CHECK não são lidas.
+func SQLite(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	b := newBuilder(dialect.SQLite, nil)
+
+	// 1. Listar as tabelas, lidas por completo antes das demais consultas, pois um banco em memória usa uma única conexão
+	type sqliteTable struct{ name, sql string }
+	var tables []sqliteTable
+	err := query(ctx, db, "SELECT name, sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name", func(rows *sql.Rows) error {
+		var t sqliteTable
+		if err := rows.Scan(&t.name, &t.sql); err != nil {
+			return err
+		}
+		tables = append(tables, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tables {
+		table := dialect.SQLite.QuoteIdent(t.name)
+
+		// 2. Ler as colunas e a chave primária, na ordem das colunas da chave
+		type keyColumn struct {
+			name     string
+			position int
+		}
+		var primaryKey []keyColumn
+		var columns []config.Column
+		var types []string
+		err := query(ctx, db, fmt.Sprintf("PRAGMA table_info(%s)", table), func(rows *sql.Rows) error {
+			var cid, notNull, pk int
+			var name, columnType string
+			var defaultValue sql.NullString
+			if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+				return err
+			}
+			if pk > 0 {
+				primaryKey = append(primaryKey, keyColumn{name, pk})
+			}
+			columns = append(columns, config.Column{Name: name, Nullable: notNull == 0 && pk == 0, Default: defaultValue.String})
+			types = append(types, columnType)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(primaryKey, func(i, j int) bool { return primaryKey[i].position < primaryKey[j].position })
+
+		// Uma coluna INTEGER PRIMARY KEY declarada com AUTOINCREMENT é uma coluna auto incremento
+		autoIncrement := len(primaryKey) == 1 && sqliteAutoIncrement.MatchString(t.sql)
+		for i, column := range columns {
+			isAutoIncrement := autoIncrement && column.Name == primaryKey[0].name
+			b.column(t.name, column.Name, types[i], column.Nullable, column.Default, isAutoIncrement)
+		}
+		for _, column := range primaryKey {
+			b.primaryKeyColumn(t.name, column.name)
+		}
+
+		// 3. Ler os índices: os criados com CREATE INDEX (origem "c") e os das restrições UNIQUE (origem "u")
+		type sqliteIndex struct {
+			name   string
+			unique bool
+			origin string
+		}
+		var indexes []sqliteIndex
+		err = query(ctx, db, fmt.Sprintf("PRAGMA index_list(%s)", table), func(rows *sql.Rows) error {
+			var seq, unique, partial int
+			var index sqliteIndex
+			if err := rows.Scan(&seq, &index.name, &unique, &index.origin, &partial); err != nil {
+				return err
+			}
+			index.unique = unique == 1
+			indexes = append(indexes, index)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// O PRAGMA lista os índices do mais recente para o mais antigo
+		for i := len(indexes) - 1; i >= 0; i-- {
+			index := indexes[i]
+			if index.origin == "pk" {
+				continue
+			}
+			err := query(ctx, db, fmt.Sprintf("PRAGMA index_info(%s)", dialect.SQLite.QuoteIdent(index.name)), func(rows *sql.Rows) error {
+				var seqNo, cid int
+				var column sql.NullString
+				if err := rows.Scan(&seqNo, &cid, &column); err != nil {
+					return err
+				}
+				if index.origin == "u" {
+					b.uniqueColumn(t.name, index.name, "", column.String)
+				} else {
+					b.indexColumn(t.name, index.name, index.unique, column.String)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// 4. Ler as chaves estrangeiras, agrupadas pelo id
+		err = query(ctx, db, fmt.Sprintf("PRAGMA foreign_key_list(%s)", table), func(rows *sql.Rows) error {
+			var id, seq int
+			var refTable, from, onUpdate, onDelete, match string
+			var to sql.NullString
+			if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+				return err
+			}
+			b.foreignKeyColumn(t.name, fmt.Sprint(id), "", from, refTable, to.String, onDelete, onUpdate)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.schemas(), nil
+}
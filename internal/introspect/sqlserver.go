@@ -0,0 +1,134 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// SQLServer lê as tabelas de usuário do banco atual a partir das visões sys.*. Colunas IDENTITY são retornadas
+// como auto incremento. Restrições CHECK não são lidas.
+func SQLServer(ctx context.Context, db *sql.DB) ([]config.Schema, error) {
+	b := newBuilder(dialect.SQLServer, nil)
+
+	// 1. Ler as colunas, com o valor padrão da restrição DEFAULT associada
+	err := query(ctx, db, `SELECT t.name, c.name, ty.name, c.max_length, c.precision, c.scale, c.is_nullable, c.is_identity,
+  COALESCE(dc.definition, '')
+FROM sys.tables t
+JOIN sys.columns c ON c.object_id = t.object_id
+JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+LEFT JOIN sys.default_constraints dc ON dc.object_id = c.default_object_id
+WHERE t.is_ms_shipped = 0
+ORDER BY t.name, c.column_id`, func(rows *sql.Rows) error {
+		var table, column, typeName, defaultValue string
+		var maxLength, precision, scale int
+		var nullable, identity bool
+		if err := rows.Scan(&table, &column, &typeName, &maxLength, &precision, &scale, &nullable, &identity, &defaultValue); err != nil {
+			return err
+		}
+		b.column(table, column, sqlServerType(typeName, maxLength, precision, scale), nullable, unwrapParentheses(defaultValue), identity)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Ler a chave primária, as restrições UNIQUE e os índices, com uma linha por coluna
+	err = query(ctx, db, `SELECT t.name, i.name, i.is_primary_key, i.is_unique_constraint, i.is_unique, c.name
+FROM sys.indexes i
+JOIN sys.tables t ON t.object_id = i.object_id
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE t.is_ms_shipped = 0 AND i.type > 0 AND ic.is_included_column = 0
+ORDER BY t.name, i.name, ic.key_ordinal`, func(rows *sql.Rows) error {
+		var table, index, column string
+		var primaryKey, uniqueConstraint, unique bool
+		if err := rows.Scan(&table, &index, &primaryKey, &uniqueConstraint, &unique, &column); err != nil {
+			return err
+		}
+		switch {
+		case primaryKey:
+			b.primaryKeyColumn(table, column)
+		case uniqueConstraint:
+			b.uniqueColumn(table, index, index, column)
+		default:
+			b.indexColumn(table, index, unique, column)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Ler as chaves estrangeiras
+	err = query(ctx, db, `SELECT t.name, fk.name, c.name, rt.name, rc.name, fk.delete_referential_action_desc,
+  fk.update_referential_action_desc
+FROM sys.foreign_keys fk
+JOIN sys.tables t ON t.object_id = fk.parent_object_id
+JOIN sys.tables rt ON rt.object_id = fk.referenced_object_id
+JOIN sys.foreign_key_columns fkc ON fkc.constraint_object_id = fk.object_id
+JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id
+JOIN sys.columns rc ON rc.object_id = fkc.referenced_object_id AND rc.column_id = fkc.referenced_column_id
+ORDER BY t.name, fk.name, fkc.constraint_column_id`, func(rows *sql.Rows) error {
+		var table, name, column, refTable, refColumn, onDelete, onUpdate string
+		if err := rows.Scan(&table, &name, &column, &refTable, &refColumn, &onDelete, &onUpdate); err != nil {
+			return err
+		}
+		b.foreignKeyColumn(table, name, name, column, refTable, refColumn, onDelete, onUpdate)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.schemas(), nil
+}
+
+// sqlServerType monta o tipo da coluna, como usado no DDL, a partir das colunas de sys.columns. max_length é
+// dado em bytes, com -1 para (MAX), e os tipos nvarchar e nchar usam dois bytes por caractere.
+func sqlServerType(typeName string, maxLength int, precision int, scale int) string {
+	name := strings.ToUpper(typeName)
+	switch name {
+	case "VARCHAR", "CHAR", "VARBINARY", "BINARY", "NVARCHAR", "NCHAR":
+		if maxLength == -1 {
+			return name + "(MAX)"
+		}
+		if strings.HasPrefix(name, "N") {
+			maxLength /= 2
+		}
+		return dialect.SizedType(name, maxLength, 0)
+	case "DECIMAL", "NUMERIC":
+		return dialect.SizedType("DECIMAL", precision, scale)
+	}
+	return name
+}
+
+// unwrapParentheses remove os parênteses externos com que o SQL Server guarda os valores padrão, como ((0))
+// e (getdate()).
+func unwrapParentheses(value string) string {
+	value = strings.TrimSpace(value)
+	for strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") && balanced(value[1:len(value)-1]) {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// balanced indica se os parênteses do valor estão balanceados, de modo que "(a) + (b)" não perca os externos.
+func balanced(value string) bool {
+	depth := 0
+	for _, r := range value {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
@@ -0,0 +1,63 @@
+package source
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Zip retorna a Source dos arquivos de migração da raiz de um pacote zip, lido de r com o tamanho informado
+// (por exemplo, um *os.File e o tamanho do arquivo).
+func Zip(r io.ReaderAt, size int64) (Source, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler o pacote zip de migrações: %v", err)
+	}
+	return FS(archive), nil
+}
+
+// Tar retorna a Source dos arquivos de migração da raiz de um pacote tar, lido por completo de r. Para pacotes
+// compactados (.tar.gz), passe um gzip.Reader.
+func Tar(r io.Reader) (Source, error) {
+	files := make(map[string][]byte)
+	var names []string
+
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Erro ao ler o pacote tar de migrações: %v", err)
+		}
+		// Apenas os arquivos da raiz são migrações, assim como em um diretório
+		if header.Typeflag != tar.TypeReg || strings.Contains(strings.TrimPrefix(header.Name, "./"), "/") {
+			continue
+		}
+
+		content, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("Erro ao ler o arquivo %s do pacote tar de migrações: %v", header.Name, err)
+		}
+		name := strings.TrimPrefix(header.Name, "./")
+		files[name] = content
+		names = append(names, name)
+	}
+
+	c, err := newCatalog(names)
+	if err != nil {
+		return nil, err
+	}
+	source := newMemorySource(c.migrations)
+	for _, m := range c.migrations {
+		source.up[m.Version] = files[m.Name]
+		if m.DownName != "" {
+			source.down[m.Version] = files[m.DownName]
+		}
+	}
+	return source, nil
+}
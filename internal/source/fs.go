@@ -0,0 +1,78 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// fsSource lê as migrações dos arquivos da raiz de um fs.FS.
+type fsSource struct {
+	fsys fs.FS
+	dir  string // Diretório de origem, quando criada por Dir, verificado antes de cada leitura
+}
+
+// FS retorna a Source dos arquivos de migração da raiz de fsys, como um diretório embutido com go:embed
+// (use fs.Sub para usar um subdiretório como raiz). Os subdiretórios são ignorados.
+func FS(fsys fs.FS) Source {
+	return &fsSource{fsys: fsys}
+}
+
+// Dir retorna a Source dos arquivos de migração do diretório informado, lidos com os.DirFS.
+func Dir(dir string) Source {
+	return &fsSource{fsys: os.DirFS(dir), dir: dir}
+}
+
+// catalog lê os nomes dos arquivos a cada chamada, de modo que arquivos criados depois sejam encontrados.
+func (s *fsSource) catalog() (*catalog, error) {
+	if s.dir != "" {
+		if _, err := os.Stat(s.dir); os.IsNotExist(err) {
+			return nil, fmt.Errorf("O diretório de migrações não existe")
+		}
+	}
+
+	entries, err := fs.ReadDir(s.fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao listar arquivos de migração: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return newCatalog(names)
+}
+
+func (s *fsSource) Migrations() ([]Migration, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+	return c.migrations, nil
+}
+
+func (s *fsSource) OpenUp(version int64) (io.ReadCloser, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+	name, err := c.upName(version)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.Open(name)
+}
+
+func (s *fsSource) OpenDown(version int64) (io.ReadCloser, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+	name, err := c.downName(version)
+	if err != nil {
+		return nil, err
+	}
+	return s.fsys.Open(name)
+}
@@ -0,0 +1,141 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// HTTPTimeout é o tempo limite de cada requisição do cliente padrão de HTTP, para que um servidor parado não
+// bloqueie a execução das migrações, e o lock de migração, indefinidamente.
+const HTTPTimeout = 30 * time.Second
+
+// Manifest é o documento JSON, servido por HTTP, que lista os arquivos de migração, por exemplo:
+//
+//	{"files": ["migration_20240101120000.up.sql", "migration_20240101120000.down.sql"]}
+//
+// Os nomes são resolvidos em relação à URL do manifesto e seguem as mesmas regras dos arquivos de um diretório.
+type Manifest struct {
+	Files []string `json:"files"`
+}
+
+// httpSource lê as migrações de um servidor HTTP, a partir de um manifesto.
+type httpSource struct {
+	client   *http.Client
+	manifest *url.URL
+
+	mu     sync.Mutex
+	listed *catalog // Catálogo da última listagem, usado para abrir os arquivos
+}
+
+// HTTP retorna a Source das migrações listadas no manifesto (veja Manifest) servido em manifestURL.
+// O manifesto é lido a cada listagem, e os arquivos abertos depois dela são procurados nessa mesma leitura;
+// os arquivos são baixados apenas quando abertos. Com client nil, é usado um cliente com o tempo limite
+// HTTPTimeout em cada requisição.
+func HTTP(client *http.Client, manifestURL string) (Source, error) {
+	parsed, err := url.Parse(manifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("URL do manifesto de migrações inválida: %v", err)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: HTTPTimeout}
+	}
+	return &httpSource{client: client, manifest: parsed}, nil
+}
+
+// get faz a requisição GET da URL e retorna o corpo da resposta, que deve ter o status 200.
+func (s *httpSource) get(target *url.URL) (io.ReadCloser, error) {
+	response, err := s.client.Get(target.String())
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao acessar %s: %v", target, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, fmt.Errorf("Erro ao acessar %s: %s", target, response.Status)
+	}
+	return response.Body, nil
+}
+
+// fetch lê o manifesto e guarda o catálogo para as próximas aberturas de arquivos.
+func (s *httpSource) fetch() (*catalog, error) {
+	body, err := s.get(s.manifest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var manifest Manifest
+	content, err := ioutil.ReadAll(body)
+	if err == nil {
+		err = json.Unmarshal(content, &manifest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao ler o manifesto de migrações %s: %v", s.manifest, err)
+	}
+	c, err := newCatalog(manifest.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.listed = c
+	s.mu.Unlock()
+	return c, nil
+}
+
+// catalog retorna o catálogo da última listagem, lendo o manifesto apenas se ele ainda não foi lido.
+func (s *httpSource) catalog() (*catalog, error) {
+	s.mu.Lock()
+	c := s.listed
+	s.mu.Unlock()
+	if c != nil {
+		return c, nil
+	}
+	return s.fetch()
+}
+
+func (s *httpSource) Migrations() ([]Migration, error) {
+	c, err := s.fetch()
+	if err != nil {
+		return nil, err
+	}
+	return c.migrations, nil
+}
+
+func (s *httpSource) OpenUp(version int64) (io.ReadCloser, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+	name, err := c.upName(version)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(name)
+}
+
+func (s *httpSource) OpenDown(version int64) (io.ReadCloser, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+	name, err := c.downName(version)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(name)
+}
+
+// open baixa o arquivo, com o nome resolvido em relação à URL do manifesto.
+func (s *httpSource) open(name string) (io.ReadCloser, error) {
+	file, err := s.manifest.Parse(url.PathEscape(name))
+	if err != nil {
+		return nil, fmt.Errorf("Nome de arquivo de migração inválido no manifesto: %s", name)
+	}
+	return s.get(file)
+}
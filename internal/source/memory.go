@@ -0,0 +1,99 @@
+package source
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// SQLMigration é uma migração SQL declarada no código, usada com Slice.
+type SQLMigration struct {
+	Version int64  // Versão da migração
+	Name    string // Nome registrado na tabela de histórico; quando vazio, migration_<versão>
+	Up      string // Comandos que aplicam a migração
+	Down    string // Comandos que revertem a migração, vazio se ela não puder ser revertida
+}
+
+// Slice retorna a Source das migrações declaradas no código, em qualquer ordem.
+// Retorna erro se duas migrações tiverem a mesma versão ou se alguma não tiver versão positiva.
+func Slice(migrations ...SQLMigration) (Source, error) {
+	list := make([]Migration, 0, len(migrations))
+	seen := make(map[int64]string, len(migrations))
+	for _, m := range migrations {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("Versão inválida na migração %s: %d", m.Name, m.Version)
+		}
+		if m.Name == "" {
+			m.Name = fmt.Sprintf("migration_%d", m.Version)
+		}
+		if other, ok := seen[m.Version]; ok {
+			return nil, fmt.Errorf("Versão %d duplicada nas migrações %s e %s", m.Version, other, m.Name)
+		}
+		seen[m.Version] = m.Name
+
+		migration := Migration{Version: m.Version, Name: m.Name}
+		if m.Down != "" {
+			migration.DownName = m.Name
+		}
+		list = append(list, migration)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	source := newMemorySource(list)
+	for _, m := range migrations {
+		source.up[m.Version] = []byte(m.Up)
+		if m.Down != "" {
+			source.down[m.Version] = []byte(m.Down)
+		}
+	}
+	return source, nil
+}
+
+// memorySource guarda em memória o conteúdo das migrações, pela versão.
+type memorySource struct {
+	migrations []Migration
+	names      map[int64]string
+	up         map[int64][]byte
+	down       map[int64][]byte
+}
+
+// newMemorySource cria a origem das migrações, já ordenadas, sem conteúdo.
+func newMemorySource(migrations []Migration) *memorySource {
+	s := &memorySource{
+		migrations: migrations,
+		names:      make(map[int64]string, len(migrations)),
+		up:         make(map[int64][]byte, len(migrations)),
+		down:       make(map[int64][]byte, len(migrations)),
+	}
+	for _, m := range migrations {
+		s.names[m.Version] = m.Name
+	}
+	return s
+}
+
+func (s *memorySource) Migrations() ([]Migration, error) {
+	return s.migrations, nil
+}
+
+func (s *memorySource) OpenUp(version int64) (io.ReadCloser, error) {
+	content, ok := s.up[version]
+	if !ok {
+		return nil, fmt.Errorf("A versão %d não existe nas migrações", version)
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (s *memorySource) OpenDown(version int64) (io.ReadCloser, error) {
+	content, ok := s.down[version]
+	if !ok {
+		if _, exists := s.up[version]; exists {
+			return nil, fmt.Errorf("A migração %s não possui arquivo down", s.names[version])
+		}
+		return nil, fmt.Errorf("A versão %d não existe nas migrações", version)
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
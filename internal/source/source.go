@@ -0,0 +1,128 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sufixos dos arquivos de migração. Arquivos terminados apenas em .sql são tratados como migrações "up".
+const (
+	UpSuffix   = ".up.sql"
+	DownSuffix = ".down.sql"
+)
+
+// versionPattern localiza a versão (sequência de dígitos) no nome do arquivo de migração.
+var versionPattern = regexp.MustCompile(`[0-9]+`)
+
+// Migration descreve uma migração disponível em uma Source.
+type Migration struct {
+	Version  int64  // Versão da migração, que define a ordem de execução
+	Name     string // Nome da migração, registrado na tabela de histórico (o nome do arquivo up)
+	DownName string // Nome da reversão (o nome do arquivo down), vazio se a migração não puder ser revertida
+}
+
+// Source é a origem das migrações: um diretório, um fs.FS, um pacote tar ou zip, um servidor HTTP ou uma
+// lista declarada no código. Outras origens podem ser integradas implementando esta interface.
+type Source interface {
+	// Migrations lista as migrações disponíveis, ordenadas pela versão.
+	Migrations() ([]Migration, error)
+	// OpenUp abre o conteúdo SQL que aplica a migração da versão informada.
+	OpenUp(version int64) (io.ReadCloser, error)
+	// OpenDown abre o conteúdo SQL que reverte a migração da versão informada.
+	OpenDown(version int64) (io.ReadCloser, error)
+}
+
+// parseVersion extrai a versão do nome de um arquivo de migração, por exemplo migration_20240101120000.up.sql.
+func parseVersion(fileName string) (int64, error) {
+	digits := versionPattern.FindString(fileName)
+	if digits == "" {
+		return 0, fmt.Errorf("Nome de arquivo de migração sem versão: %s", fileName)
+	}
+	version, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Versão inválida no arquivo de migração %s: %v", fileName, err)
+	}
+	return version, nil
+}
+
+// catalog associa as migrações às versões, a partir dos nomes dos arquivos, e é usado pelas origens
+// baseadas em arquivos.
+type catalog struct {
+	migrations []Migration
+	byVersion  map[int64]Migration
+}
+
+// newCatalog organiza os arquivos .sql em migrações ordenadas pela versão, associando cada arquivo up ao seu down.
+// Os demais arquivos são ignorados. Retorna erro se dois arquivos do mesmo tipo tiverem a mesma versão ou se
+// existir um down sem o up correspondente.
+func newCatalog(fileNames []string) (*catalog, error) {
+	byVersion := make(map[int64]*Migration)
+	downs := make(map[int64]string)
+	for _, name := range fileNames {
+		if path.Ext(name) != ".sql" {
+			continue
+		}
+
+		version, err := parseVersion(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasSuffix(name, DownSuffix) {
+			if other, ok := downs[version]; ok {
+				return nil, fmt.Errorf("Versão %d duplicada nos arquivos de migração %s e %s", version, other, name)
+			}
+			downs[version] = name
+			continue
+		}
+
+		if other, ok := byVersion[version]; ok {
+			return nil, fmt.Errorf("Versão %d duplicada nos arquivos de migração %s e %s", version, other.Name, name)
+		}
+		byVersion[version] = &Migration{Version: version, Name: name}
+	}
+
+	for version, downName := range downs {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("Arquivo de migração %s não possui o arquivo up correspondente", downName)
+		}
+		m.DownName = downName
+	}
+
+	c := &catalog{byVersion: make(map[int64]Migration, len(byVersion))}
+	for version, m := range byVersion {
+		c.migrations = append(c.migrations, *m)
+		c.byVersion[version] = *m
+	}
+	sort.Slice(c.migrations, func(i, j int) bool {
+		return c.migrations[i].Version < c.migrations[j].Version
+	})
+	return c, nil
+}
+
+// upName retorna o nome do arquivo up da versão.
+func (c *catalog) upName(version int64) (string, error) {
+	m, ok := c.byVersion[version]
+	if !ok {
+		return "", fmt.Errorf("A versão %d não existe nas migrações", version)
+	}
+	return m.Name, nil
+}
+
+// downName retorna o nome do arquivo down da versão.
+func (c *catalog) downName(version int64) (string, error) {
+	m, ok := c.byVersion[version]
+	if !ok {
+		return "", fmt.Errorf("A versão %d não existe nas migrações", version)
+	}
+	if m.DownName == "" {
+		return "", fmt.Errorf("A migração %s não possui arquivo down", m.Name)
+	}
+	return m.DownName, nil
+}
@@ -0,0 +1,198 @@
+package source_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+	"github.com/stretchr/testify/assert"
+)
+
+// files são os arquivos de migração usados nos testes, incluindo um arquivo que não é de migração.
+var files = map[string]string{
+	"migration_20240102000000.up.sql":   "CREATE TABLE posts (id INTEGER);",
+	"migration_20240101000000.up.sql":   "CREATE TABLE users (id INTEGER);",
+	"migration_20240101000000.down.sql": "DROP TABLE users;",
+	"README.md":                         "Não é uma migração",
+}
+
+// read lê o conteúdo aberto pela função, falhando o teste em caso de erro.
+func read(t *testing.T, src source.Source, down bool, version int64) string {
+	t.Helper()
+	open := src.OpenUp
+	if down {
+		open = src.OpenDown
+	}
+	r, err := open(version)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer r.Close()
+	content, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	return string(content)
+}
+
+// assertSource verifica uma origem criada a partir de files.
+func assertSource(t *testing.T, src source.Source) {
+	t.Helper()
+
+	// As migrações são ordenadas pela versão, e a sem arquivo down não pode ser revertida
+	migrations, err := src.Migrations()
+	assert.NoError(t, err)
+	assert.Equal(t, []source.Migration{
+		{Version: 20240101000000, Name: "migration_20240101000000.up.sql", DownName: "migration_20240101000000.down.sql"},
+		{Version: 20240102000000, Name: "migration_20240102000000.up.sql"},
+	}, migrations)
+
+	assert.Equal(t, "CREATE TABLE users (id INTEGER);", read(t, src, false, 20240101000000))
+	assert.Equal(t, "DROP TABLE users;", read(t, src, true, 20240101000000))
+
+	_, err = src.OpenDown(20240102000000)
+	assert.EqualError(t, err, "A migração migration_20240102000000.up.sql não possui arquivo down")
+	_, err = src.OpenUp(20240103000000)
+	assert.EqualError(t, err, "A versão 20240103000000 não existe nas migrações")
+}
+
+func TestFS(t *testing.T) {
+	fsys := fstest.MapFS{"subdir/migration_20240103000000.up.sql": {Data: []byte("SELECT 1;")}}
+	for name, content := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(content)}
+	}
+	assertSource(t, source.FS(fsys))
+
+	// Duas migrações com a mesma versão
+	fsys["migration_20240101000000_users.up.sql"] = &fstest.MapFile{}
+	_, err := source.FS(fsys).Migrations()
+	assert.Error(t, err)
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	src := source.Dir(dir)
+
+	// Os arquivos criados depois da criação da origem são encontrados
+	for name, content := range files {
+		assert.NoError(t, ioutil.WriteFile(dir+"/"+name, []byte(content), 0644))
+	}
+	assertSource(t, src)
+
+	_, err := source.Dir(dir + "/inexistente").Migrations()
+	assert.EqualError(t, err, "O diretório de migrações não existe")
+}
+
+func TestZip(t *testing.T) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+
+	src, err := source.Zip(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assertSource(t, src)
+
+	_, err = source.Zip(bytes.NewReader([]byte("inválido")), 8)
+	assert.Error(t, err)
+}
+
+func TestTar(t *testing.T) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	add := func(name string, content string) {
+		assert.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	for name, content := range files {
+		add("./"+name, content)
+	}
+	// Arquivos em subdiretórios são ignorados
+	add("subdir/migration_20240103000000.up.sql", "SELECT 1;")
+	assert.NoError(t, w.Close())
+
+	src, err := source.Tar(&buf)
+	assert.NoError(t, err)
+	assertSource(t, src)
+}
+
+func TestHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	manifestRequests := 0
+	mux.HandleFunc("/migrations/manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		manifestRequests++
+		w.Write([]byte(`{"files": ["migration_20240101000000.up.sql", "migration_20240101000000.down.sql", "migration_20240102000000.up.sql"]}`))
+	})
+	for name, content := range files {
+		content := content
+		mux.HandleFunc("/migrations/"+name, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(content))
+		})
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	src, err := source.HTTP(nil, server.URL+"/migrations/manifest.json")
+	assert.NoError(t, err)
+	assertSource(t, src)
+
+	// O manifesto é lido uma vez por listagem, e não a cada arquivo aberto
+	manifestRequests = 0
+	_, err = src.Migrations()
+	assert.NoError(t, err)
+	read(t, src, false, 20240101000000)
+	read(t, src, true, 20240101000000)
+	assert.Equal(t, 1, manifestRequests)
+
+	// Um servidor que não responde é interrompido pelo tempo limite do cliente
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer stalled.Close()
+	src, err = source.HTTP(&http.Client{Timeout: 50 * time.Millisecond}, stalled.URL+"/manifest.json")
+	assert.NoError(t, err)
+	_, err = src.Migrations()
+	assert.Error(t, err)
+
+	// Um manifesto inexistente é um erro
+	src, err = source.HTTP(server.Client(), server.URL+"/outro/manifest.json")
+	assert.NoError(t, err)
+	_, err = src.Migrations()
+	assert.Error(t, err)
+}
+
+func TestSlice(t *testing.T) {
+	src, err := source.Slice(
+		source.SQLMigration{Version: 2, Up: "CREATE TABLE posts (id INTEGER);"},
+		source.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);", Down: "DROP TABLE users;"},
+	)
+	assert.NoError(t, err)
+
+	migrations, err := src.Migrations()
+	assert.NoError(t, err)
+	assert.Equal(t, []source.Migration{
+		{Version: 1, Name: "users", DownName: "users"},
+		{Version: 2, Name: "migration_2"},
+	}, migrations)
+	assert.Equal(t, "DROP TABLE users;", read(t, src, true, 1))
+
+	_, err = src.OpenDown(2)
+	assert.EqualError(t, err, "A migração migration_2 não possui arquivo down")
+
+	// Versões duplicadas ou inválidas
+	_, err = source.Slice(source.SQLMigration{Version: 1, Up: "A"}, source.SQLMigration{Version: 1, Up: "B"})
+	assert.EqualError(t, err, "Versão 1 duplicada nas migrações migration_1 e migration_1")
+	_, err = source.Slice(source.SQLMigration{Version: 0, Name: "zero"})
+	assert.Error(t, err)
+}
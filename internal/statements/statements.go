@@ -0,0 +1,354 @@
+package statements
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+)
+
+// Statement é um comando de um script SQL, enviado sozinho ao banco de dados.
+type Statement struct {
+	SQL  string `json:"sql"`  // Texto do comando, sem o terminador
+	Line int    `json:"line"` // Linha do script, a partir de 1, em que o comando começa
+}
+
+// syntax descreve as regras léxicas de um dialeto que afetam a divisão dos comandos.
+type syntax struct {
+	delimiter          string // Terminador inicial dos comandos; vazio quando apenas o separador de lotes divide o script
+	batchSeparator     string // Comando que, sozinho em uma linha, encerra um lote (como o GO do SQL Server)
+	backslashEscapes   bool   // Textos entre aspas aceitam escapes com "\" (MySQL)
+	backticks          bool   // Identificadores entre crases (MySQL)
+	brackets           bool   // Identificadores entre colchetes (SQL Server)
+	hashComments       bool   // Comentários iniciados por "#" (MySQL)
+	executableComments bool   // Comentários /*! ... */ que o banco executa (MySQL)
+	spacedDashComments bool   // "--" só inicia um comentário se seguido de espaço (MySQL)
+	nestedComments     bool   // Comentários /* */ aninhados (PostgreSQL)
+	dollarQuotes       bool   // Textos entre $$ ou $tag$ e textos E'...' com escapes (PostgreSQL)
+	delimiterCommand   bool   // Comando DELIMITER do cliente, que troca o terminador (MySQL)
+	setTerm            bool   // Comando SET TERM, que troca o terminador (Firebird)
+	triggerBlocks      bool   // Gatilhos com blocos BEGIN ... END contendo ";" (SQLite)
+}
+
+// syntaxOf retorna as regras do dialeto. Dialetos sem regras próprias dividem os comandos por ";",
+// respeitando textos e comentários do padrão SQL, e pelo seu separador de lotes.
+func syntaxOf(d dialect.Dialect) syntax {
+	s := syntax{delimiter: ";", batchSeparator: d.BatchSeparator()}
+	switch d.Name() {
+	case dialect.MySQL.Name():
+		s.backslashEscapes = true
+		s.backticks = true
+		s.hashComments = true
+		s.executableComments = true
+		s.spacedDashComments = true
+		s.delimiterCommand = true
+	case dialect.PostgreSQL.Name():
+		s.nestedComments = true
+		s.dollarQuotes = true
+	case dialect.Firebird.Name():
+		s.setTerm = true
+	case dialect.SQLite.Name():
+		s.backticks = true
+		s.brackets = true
+		s.triggerBlocks = true
+	case dialect.SQLServer.Name():
+		// Os lotes do SQL Server são enviados inteiros, pois procedimentos e gatilhos contêm ";"
+		s.delimiter = ""
+		s.brackets = true
+	}
+	return s
+}
+
+var (
+	// delimiterPattern corresponde ao comando DELIMITER do cliente do MySQL.
+	delimiterPattern = regexp.MustCompile(`(?i)^\s*DELIMITER\s+(\S+)\s*$`)
+	// setTermPattern corresponde ao comando SET TERM do Firebird, já sem o terminador.
+	setTermPattern = regexp.MustCompile(`(?is)^SET\s+TERM\s+(\S+)$`)
+	// createTriggerPattern corresponde ao início da criação de um gatilho no SQLite.
+	createTriggerPattern = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\b`)
+	// dollarTagPattern corresponde à abertura de um texto entre $$ ou $tag$ do PostgreSQL.
+	dollarTagPattern = regexp.MustCompile(`^\$(?:[A-Za-z_][A-Za-z0-9_]*)?\$`)
+)
+
+// Split divide o script de uma migração nos comandos que o compõem, de acordo com o dialeto: os terminadores
+// dentro de textos, identificadores entre aspas e comentários são ignorados, assim como os textos entre $$ do
+// PostgreSQL e os blocos BEGIN ... END dos gatilhos do SQLite. Os comandos DELIMITER do MySQL e SET TERM do
+// Firebird trocam o terminador e não são retornados. No SQL Server, o script é dividido apenas nos lotes
+// separados por GO, e um lote seguido de "GO n" é retornado n vezes.
+// Comentários antes de um comando não fazem parte dele, e trechos apenas com comentários são descartados.
+// Retorna erro se um texto, identificador ou comentário não for terminado.
+func Split(d dialect.Dialect, script string) ([]Statement, error) {
+	s := syntaxOf(d)
+	p := &splitter{syntax: s, script: script, line: 1, delimiter: s.delimiter, start: -1}
+	if s.batchSeparator != "" {
+		p.batchPattern = regexp.MustCompile(`(?i)^\s*` + regexp.QuoteMeta(s.batchSeparator) + `(?:\s+(\d+))?\s*(?:--.*)?$`)
+	}
+	if err := p.split(); err != nil {
+		return nil, err
+	}
+	return p.statements, nil
+}
+
+// splitter percorre o script, acumulando o comando atual.
+type splitter struct {
+	syntax
+	batchPattern *regexp.Regexp // Linha com o separador de lotes, opcionalmente seguido do número de repetições
+	script       string
+	pos          int    // Posição atual no script
+	line         int    // Linha da posição atual
+	delimiter    string // Terminador atual
+	start        int    // Início do comando atual, -1 enquanto houver apenas espaços e comentários
+	startLine    int    // Linha do início do comando atual
+	depth        int    // Profundidade dos blocos BEGIN ... END do gatilho atual
+	statements   []Statement
+}
+
+func (p *splitter) split() error {
+	for p.pos < len(p.script) {
+		if p.pos == 0 || p.script[p.pos-1] == '\n' {
+			if p.clientCommand() {
+				continue
+			}
+		}
+
+		c := p.script[p.pos]
+		rest := p.script[p.pos:]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.advance(1)
+		case strings.HasPrefix(rest, "--") && (!p.spacedDashComments || len(rest) == 2 || isSpace(rest[2])):
+			p.skipLine()
+		case c == '#' && p.hashComments:
+			p.skipLine()
+		case strings.HasPrefix(rest, "/*"):
+			if p.executableComments && strings.HasPrefix(rest, "/*!") {
+				p.mark()
+			}
+			if err := p.skipComment(); err != nil {
+				return err
+			}
+		case c == '\'':
+			p.mark()
+			if err := p.skipQuoted('\'', p.backslashEscapes || p.escapeString()); err != nil {
+				return err
+			}
+		case c == '"':
+			p.mark()
+			if err := p.skipQuoted('"', p.backslashEscapes); err != nil {
+				return err
+			}
+		case c == '`' && p.backticks:
+			p.mark()
+			if err := p.skipQuoted('`', false); err != nil {
+				return err
+			}
+		case c == '[' && p.brackets:
+			p.mark()
+			if err := p.skipQuoted(']', false); err != nil {
+				return err
+			}
+		case c == '$' && p.dollarQuotes && dollarTagPattern.MatchString(rest):
+			p.mark()
+			if err := p.skipDollarQuoted(dollarTagPattern.FindString(rest)); err != nil {
+				return err
+			}
+		case p.delimiter != "" && p.depth == 0 && strings.HasPrefix(rest, p.delimiter):
+			end := p.pos
+			p.advance(len(p.delimiter))
+			p.flush(end, 1)
+		case isWordStart(c):
+			p.mark()
+			p.word()
+		default:
+			p.mark()
+			p.advance(1)
+		}
+	}
+	p.flush(len(p.script), 1)
+	return nil
+}
+
+// clientCommand trata as linhas com comandos do cliente, que não são enviados ao banco: o separador de lotes
+// e o DELIMITER do MySQL. Retorna true se a linha atual foi consumida.
+func (p *splitter) clientCommand() bool {
+	end := strings.IndexByte(p.script[p.pos:], '\n')
+	if end < 0 {
+		end = len(p.script)
+	} else {
+		end += p.pos
+	}
+	line := strings.TrimRight(p.script[p.pos:end], "\r")
+
+	if p.batchPattern != nil {
+		if match := p.batchPattern.FindStringSubmatch(line); match != nil {
+			count := 1
+			if match[1] != "" {
+				count, _ = strconv.Atoi(match[1])
+			}
+			p.flush(p.pos, count)
+			p.advance(end - p.pos)
+			return true
+		}
+	}
+
+	if p.delimiterCommand && p.start < 0 {
+		if match := delimiterPattern.FindStringSubmatch(line); match != nil {
+			p.delimiter = match[1]
+			p.advance(end - p.pos)
+			return true
+		}
+	}
+	return false
+}
+
+// flush encerra o comando atual na posição end, acrescentando-o count vezes aos comandos.
+func (p *splitter) flush(end int, count int) {
+	start := p.start
+	p.start, p.depth = -1, 0
+	if start < 0 {
+		return
+	}
+
+	sql := strings.TrimSpace(p.script[start:end])
+	if p.setTerm {
+		if match := setTermPattern.FindStringSubmatch(sql); match != nil {
+			p.delimiter = match[1]
+			return
+		}
+	}
+	for i := 0; i < count; i++ {
+		p.statements = append(p.statements, Statement{SQL: sql, Line: p.startLine})
+	}
+}
+
+// mark registra o início do comando atual, se ainda não iniciado.
+func (p *splitter) mark() {
+	if p.start < 0 {
+		p.start, p.startLine = p.pos, p.line
+	}
+}
+
+// advance avança n bytes, contando as quebras de linha.
+func (p *splitter) advance(n int) {
+	p.line += strings.Count(p.script[p.pos:p.pos+n], "\n")
+	p.pos += n
+}
+
+// skipLine avança até o fim da linha, sem consumir a quebra de linha.
+func (p *splitter) skipLine() {
+	end := strings.IndexByte(p.script[p.pos:], '\n')
+	if end < 0 {
+		end = len(p.script) - p.pos
+	}
+	p.advance(end)
+}
+
+// skipComment avança até o fim do comentário /* */ atual, considerando o aninhamento quando o dialeto o permite.
+func (p *splitter) skipComment() error {
+	line := p.line
+	p.advance(2)
+	depth := 1
+	for p.pos < len(p.script) {
+		rest := p.script[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "*/"):
+			p.advance(2)
+			if depth--; depth == 0 {
+				return nil
+			}
+		case p.nestedComments && strings.HasPrefix(rest, "/*"):
+			p.advance(2)
+			depth++
+		default:
+			p.advance(1)
+		}
+	}
+	return fmt.Errorf("Comentário não terminado, iniciado na linha %d", line)
+}
+
+// skipQuoted avança até o fim do texto ou identificador entre aspas atual, terminado por close. O caractere
+// de fechamento repetido é um escape e, com backslash, "\" escapa o caractere seguinte.
+func (p *splitter) skipQuoted(close byte, backslash bool) error {
+	open, line := p.script[p.pos], p.line
+	p.advance(1)
+	for p.pos < len(p.script) {
+		c := p.script[p.pos]
+		switch {
+		case backslash && c == '\\' && p.pos+1 < len(p.script):
+			p.advance(2)
+		case c == close && p.pos+1 < len(p.script) && p.script[p.pos+1] == close:
+			p.advance(2)
+		case c == close:
+			p.advance(1)
+			return nil
+		default:
+			p.advance(1)
+		}
+	}
+	return fmt.Errorf("Texto entre %c%c não terminado, iniciado na linha %d", open, close, line)
+}
+
+// escapeString indica se o texto entre aspas atual é um texto E'...' do PostgreSQL, que aceita escapes com "\".
+func (p *splitter) escapeString() bool {
+	if !p.dollarQuotes || p.pos == 0 || (p.script[p.pos-1] != 'E' && p.script[p.pos-1] != 'e') {
+		return false
+	}
+	return p.pos == 1 || !isWordPart(p.script[p.pos-2])
+}
+
+// skipDollarQuoted avança até o fechamento do texto entre $tag$ do PostgreSQL.
+func (p *splitter) skipDollarQuoted(tag string) error {
+	line := p.line
+	p.advance(len(tag))
+	end := strings.Index(p.script[p.pos:], tag)
+	if end < 0 {
+		return fmt.Errorf("Texto entre %s não terminado, iniciado na linha %d", tag, line)
+	}
+	p.advance(end + len(tag))
+	return nil
+}
+
+// word avança sobre uma palavra, acompanhando os blocos BEGIN ... END dos gatilhos do SQLite, nos quais
+// o terminador não encerra o comando.
+func (p *splitter) word() {
+	start := p.pos
+	for p.pos < len(p.script) && isWordPart(p.script[p.pos]) {
+		// Terminadores como $$ podem seguir uma palavra sem espaço, como em END$$
+		if p.pos > start && p.delimiter != "" && strings.HasPrefix(p.script[p.pos:], p.delimiter) {
+			break
+		}
+		p.pos++
+	}
+	if !p.triggerBlocks {
+		return
+	}
+
+	switch strings.ToUpper(p.script[start:p.pos]) {
+	case "BEGIN":
+		if p.depth > 0 || createTriggerPattern.MatchString(p.script[p.start:start]) {
+			p.depth++
+		}
+	case "CASE":
+		if p.depth > 0 {
+			p.depth++
+		}
+	case "END":
+		if p.depth > 0 {
+			p.depth--
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func isWordStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isWordPart(c byte) bool {
+	return isWordStart(c) || (c >= '0' && c <= '9') || c == '$'
+}
@@ -0,0 +1,150 @@
+package statements_test
+
+import (
+	"testing"
+
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/statements"
+	"github.com/stretchr/testify/assert"
+)
+
+// sqls retorna apenas o texto dos comandos.
+func sqls(list []statements.Statement) []string {
+	result := make([]string, len(list))
+	for i, s := range list {
+		result[i] = s.SQL
+	}
+	return result
+}
+
+func TestSplit(t *testing.T) {
+	script := `-- migrate:no-transaction
+CREATE TABLE users (id INTEGER, name TEXT); -- usuários
+
+/* comentário; com terminador */
+INSERT INTO users VALUES (1, 'O''Brien; Jr.');
+INSERT INTO "weird;name" VALUES (2,
+  'multi
+linha');
+-- comentário final;
+`
+	list, err := statements.Split(dialect.Generic, script)
+	assert.NoError(t, err)
+
+	// Os terminadores dentro de textos e comentários não dividem os comandos, e a linha é a do início do comando
+	assert.Equal(t, []statements.Statement{
+		{SQL: "CREATE TABLE users (id INTEGER, name TEXT)", Line: 2},
+		{SQL: "INSERT INTO users VALUES (1, 'O''Brien; Jr.')", Line: 5},
+		{SQL: "INSERT INTO \"weird;name\" VALUES (2,\n  'multi\nlinha')", Line: 6},
+	}, list)
+
+	// Scripts vazios ou apenas com comentários não possuem comandos
+	list, err = statements.Split(dialect.Generic, "-- nada\n/* a executar */\n")
+	assert.NoError(t, err)
+	assert.Empty(t, list)
+
+	// Textos e comentários não terminados
+	_, err = statements.Split(dialect.Generic, "SELECT 1;\nSELECT 'abc;")
+	assert.EqualError(t, err, "Texto entre '' não terminado, iniciado na linha 2")
+	_, err = statements.Split(dialect.Generic, "SELECT 1; /* abc")
+	assert.EqualError(t, err, "Comentário não terminado, iniciado na linha 1")
+}
+
+func TestSplitPostgreSQL(t *testing.T) {
+	script := `CREATE FUNCTION touch() RETURNS trigger AS $body$
+BEGIN
+  NEW.updated_at := now(); -- $$ dentro do corpo
+  RETURN NEW;
+END;
+$body$ LANGUAGE plpgsql;
+DO $$ BEGIN PERFORM 1; END $$;
+SELECT E'it\'s;', $1 /* a /* aninhado; */ b; */;`
+
+	list, err := statements.Split(dialect.PostgreSQL, script)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"CREATE FUNCTION touch() RETURNS trigger AS $body$\nBEGIN\n  NEW.updated_at := now(); -- $$ dentro do corpo\n  RETURN NEW;\nEND;\n$body$ LANGUAGE plpgsql",
+		"DO $$ BEGIN PERFORM 1; END $$",
+		"SELECT E'it\\'s;', $1 /* a /* aninhado; */ b; */",
+	}, sqls(list))
+	assert.Equal(t, 7, list[1].Line)
+
+	_, err = statements.Split(dialect.PostgreSQL, "DO $$ BEGIN")
+	assert.EqualError(t, err, "Texto entre $$ não terminado, iniciado na linha 1")
+}
+
+func TestSplitMySQL(t *testing.T) {
+	script := `/*!40101 SET NAMES utf8mb4 */;
+# comentário; do MySQL
+INSERT INTO ` + "`order;s`" + ` VALUES ('a\'b;c'), ("x\"y;");
+SELECT 1--1;
+DELIMITER $$
+CREATE TRIGGER trg BEFORE INSERT ON users FOR EACH ROW
+BEGIN
+  SET NEW.name = TRIM(NEW.name);
+END$$
+DELIMITER ;
+SELECT 2;`
+
+	list, err := statements.Split(dialect.MySQL, script)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/*!40101 SET NAMES utf8mb4 */",
+		"INSERT INTO `order;s` VALUES ('a\\'b;c'), (\"x\\\"y;\")",
+		"SELECT 1--1",
+		"CREATE TRIGGER trg BEFORE INSERT ON users FOR EACH ROW\nBEGIN\n  SET NEW.name = TRIM(NEW.name);\nEND",
+		"SELECT 2",
+	}, sqls(list))
+	assert.Equal(t, 6, list[3].Line)
+}
+
+func TestSplitFirebird(t *testing.T) {
+	script := `SET TERM ^ ;
+CREATE PROCEDURE touch AS
+BEGIN
+  UPDATE users SET name = name;
+END^
+SET TERM ; ^
+SELECT 1 FROM rdb$database;`
+
+	list, err := statements.Split(dialect.Firebird, script)
+	assert.NoError(t, err)
+	assert.Equal(t, []statements.Statement{
+		{SQL: "CREATE PROCEDURE touch AS\nBEGIN\n  UPDATE users SET name = name;\nEND", Line: 2},
+		{SQL: "SELECT 1 FROM rdb$database", Line: 7},
+	}, list)
+}
+
+func TestSplitSQLite(t *testing.T) {
+	script := `BEGIN TRANSACTION;
+CREATE TRIGGER users_touch AFTER UPDATE ON users
+BEGIN
+  UPDATE users SET name = CASE WHEN name = '' THEN NULL ELSE name END WHERE id = NEW.id;
+  INSERT INTO [audit;log] VALUES (NEW.id);
+END;
+COMMIT;`
+
+	list, err := statements.Split(dialect.SQLite, script)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"BEGIN TRANSACTION",
+		"CREATE TRIGGER users_touch AFTER UPDATE ON users\nBEGIN\n  UPDATE users SET name = CASE WHEN name = '' THEN NULL ELSE name END WHERE id = NEW.id;\n  INSERT INTO [audit;log] VALUES (NEW.id);\nEND",
+		"COMMIT",
+	}, sqls(list))
+}
+
+func TestSplitSQLServer(t *testing.T) {
+	script := "CREATE TABLE users (id INT);\ngo\nINSERT INTO users VALUES (1);\nGO 3 -- repete o lote\n\nGO\nSELECT 'GO' AS go_column;\nSELECT '\nGO\n';"
+
+	list, err := statements.Split(dialect.SQLServer, script)
+	assert.NoError(t, err)
+
+	// O lote seguido de "GO 3" é repetido, o lote vazio é descartado e GO dentro de um texto não separa lotes
+	assert.Equal(t, []statements.Statement{
+		{SQL: "CREATE TABLE users (id INT);", Line: 1},
+		{SQL: "INSERT INTO users VALUES (1);", Line: 3},
+		{SQL: "INSERT INTO users VALUES (1);", Line: 3},
+		{SQL: "INSERT INTO users VALUES (1);", Line: 3},
+		{SQL: "SELECT 'GO' AS go_column;\nSELECT '\nGO\n';", Line: 7},
+	}, list)
+}
@@ -2,23 +2,25 @@ package main
 
 import (
 	"database/sql"
-	"fmt"
+	"os"
 
+	"github.com/LuisMarchio03/golang_migration_system/internal/cli"
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
-	"github.com/LuisMarchio03/golang_migration_system/internal/exec"
+	golang_migration_system "github.com/LuisMarchio03/golang_migration_system/pkg"
 )
 
-// MigrationsDir é o diretório onde as migrações serão geradas e executadas
-var migrationsDir string // Variável global para armazenar o diretório de migrações
-
 // SetMigrationsDir configura o diretório onde as migrações serão geradas e executadas
+//
+// Deprecated: use um Migrator do pacote pkg, criado com WithDir.
 func SetMigrationsDir(dir string) {
-	migrationsDir = dir
+	golang_migration_system.SetMigrationsDir(dir)
 }
 
 // GetMigrationsDir retorna o diretório atualmente configurado para as migrações
+//
+// Deprecated: use um Migrator do pacote pkg, criado com WithDir.
 func GetMigrationsDir() string {
-	return migrationsDir
+	return golang_migration_system.GetMigrationsDir()
 }
 
 // Cfg representa a configuração do banco de dados
@@ -27,68 +29,31 @@ type Cfg = config.Cfg
 // Schema representa um esquema de tabela
 type Schema = config.Schema
 
-// ConfigDB configura e retorna uma conexão com o banco de dados
-func ExecConfigDB(dbDriver string, cfg config.Cfg, migrationsDir string) (*sql.DB, error) {
-	db, err := exec.ConfigDB(dbDriver, cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	// Define o diretório de migrações
-	SetMigrationsDir(migrationsDir)
+// Column representa uma coluna de tabela
+type Column = config.Column
 
-	return db, nil
+// ExecConfigDB configura e retorna uma conexão com o banco de dados, e define o diretório de migrações
+//
+// Deprecated: use ConfigDB e um Migrator do pacote pkg.
+func ExecConfigDB(dbDriver string, cfg config.Cfg, migrationsDir string) (*sql.DB, error) {
+	return golang_migration_system.ExecConfigDB(dbDriver, cfg, migrationsDir)
 }
 
-// GenerateMigration gera um arquivo de migração com as schemas fornecidas
+// ExecGenerateMigration gera um arquivo de migração com as schemas fornecidas
+//
+// Deprecated: use Migrator.GenerateMigration do pacote pkg.
 func ExecGenerateMigration(schemas ...config.Schema) (string, error) {
-	migrationFileName, err := exec.GenerateMigration(migrationsDir, schemas...)
-	if err != nil {
-		return "", err
-	}
-	return migrationFileName, nil
+	return golang_migration_system.ExecGenerateMigration(schemas...)
 }
 
-// RunMigrations executa todas as migrações encontradas no diretório especificado
+// ExecRunMigrations executa todas as migrações encontradas no diretório especificado
+//
+// Deprecated: use Migrator.Up do pacote pkg.
 func ExecRunMigrations(db *sql.DB, migrationsDir string) error {
-	err := exec.RunMigrations(db, migrationsDir)
-	if err != nil {
-		return err
-	}
-	return nil
+	return golang_migration_system.ExecRunMigrations(db, migrationsDir)
 }
 
+// main executa o comando migrate (veja cmd/migrate), permitindo usar o módulo diretamente com go run.
 func main() {
-	// Aqui você pode chamar suas funções conforme necessário
-	db, err := ExecConfigDB("mysql", config.Cfg{}, "migrationsDir")
-	if err != nil {
-		fmt.Println("Error configuring database:", err)
-		return
-	}
-
-	// Exemplo: gerar migrações
-	schema := config.Schema{
-		TableName: "users",
-		Fields: map[string]string{
-			"id":         "INT NOT NULL AUTO_INCREMENT PRIMARY KEY",
-			"username":   "VARCHAR(50) NOT NULL",
-			"email":      "VARCHAR(100) NOT NULL",
-			"created_at": "TIMESTAMP DEFAULT CURRENT_TIMESTAMP",
-			"updated_at": "TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP",
-		},
-	}
-	migrationFileName, err := ExecGenerateMigration(schema)
-	if err != nil {
-		fmt.Println("Error generating migration:", err)
-		return
-	}
-	fmt.Println("Migration generated successfully:", migrationFileName)
-
-	// Exemplo: executar migrações
-	err = ExecRunMigrations(db, "migrationsDir")
-	if err != nil {
-		fmt.Println("Error executing migrations:", err)
-		return
-	}
-	fmt.Println("Migrations completed successfully.")
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -1,8 +1,8 @@
 package main_test
 
 import (
-	"database/sql"
-	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	golang_migration_system "github.com/LuisMarchio03/golang_migration_system"
@@ -11,16 +11,12 @@ import (
 )
 
 func TestExecConfigDB(t *testing.T) {
-	// Configuração dos parâmetros do teste
-	dbDriver := "mysql"
+	// Configuração dos parâmetros do teste: SQLite em memória, sem depender de um servidor
+	dbDriver := "sqlite"
 	cfg := config.Cfg{
-		User:   "meu_app_user",
-		Passwd: "meu_app_password",
-		Net:    "tcp",
-		Addr:   "localhost:3308",
-		DBName: "meu_app_db",
+		Path: ":memory:",
 	}
-	migrationsDir := "."
+	migrationsDir := t.TempDir()
 
 	// Executa a função a ser testada
 	db, err := golang_migration_system.ExecConfigDB(dbDriver, cfg, migrationsDir)
@@ -33,12 +29,19 @@ func TestExecConfigDB(t *testing.T) {
 
 	// Fecha a conexão com o banco de dados no final do teste
 	defer db.Close()
+
+	// Verifica se o diretório de migrações foi definido
+	assert.Equal(t, migrationsDir, golang_migration_system.GetMigrationsDir())
 }
 
 func TestExecGenerateMigration(t *testing.T) {
+	// Define um diretório temporário para as migrações
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+
 	// Cria um exemplo de schema para teste
 	schema := config.Schema{
-		DbType:    "mysql",
+		DbType:    "sqlite",
 		TableName: "test",
 		Fields: map[string]string{
 			"id":       "INT AUTO_INCREMENT PRIMARY KEY",
@@ -55,34 +58,38 @@ func TestExecGenerateMigration(t *testing.T) {
 
 	// Verifica se o nome do arquivo de migração foi retornado corretamente
 	assert.NotEmpty(t, migrationFileName, "Nome do arquivo de migração não está definido")
+
+	// Verifica se a coluna auto incremento foi adaptada ao SQLite
+	content, err := os.ReadFile(filepath.Join(migrationsDir, migrationFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "id INTEGER PRIMARY KEY AUTOINCREMENT")
 }
 
 func TestExecRunMigrations(t *testing.T) {
-	cfg := config.Cfg{
-		User:   "meu_app_user",
-		Passwd: "meu_app_password",
-		Net:    "tcp",
-		Addr:   "localhost:3308",
-		DBName: "meu_app_db",
-	}
-	connString := fmt.Sprintf("%s:%s@%s(%s)/%s",
-		cfg.User,
-		cfg.Passwd,
-		cfg.Net,
-		cfg.Addr,
-		cfg.DBName,
-	)
-
-	// Simula a conexão com o banco de dados (pode ser feita com um banco de dados de teste)
-	db, _ := sql.Open("mysql", connString)
+	// Banco SQLite em memória
+	migrationsDir := t.TempDir()
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, migrationsDir)
+	assert.NoError(t, err, "Erro ao configurar o banco de dados")
 	defer db.Close()
 
-	// Define o diretório de migrações para teste
-	migrationsDir := "."
+	// Gera uma migração no diretório de migrações para teste
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Fields: map[string]string{
+			"id":       "INT AUTO_INCREMENT PRIMARY KEY",
+			"username": "VARCHAR(50) NOT NULL",
+		},
+	})
+	assert.NoError(t, err, "Erro ao gerar a migração")
 
 	// Executa a função a ser testada
-	err := golang_migration_system.ExecRunMigrations(db, migrationsDir)
+	err = golang_migration_system.ExecRunMigrations(db, migrationsDir)
 
 	// Verifica se não houve erro na execução das migrações
 	assert.NoError(t, err, "Erro ao executar as migrações")
+
+	// Verifica se a tabela foi criada
+	_, err = db.Exec("INSERT INTO users (username) VALUES ('luis')")
+	assert.NoError(t, err, "A tabela users não foi criada")
 }
@@ -1,32 +1,222 @@
 package golang_migration_system
 
 import (
+	"context"
 	"database/sql"
+	"io"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
+	"github.com/LuisMarchio03/golang_migration_system/internal/dialect"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drift"
+	"github.com/LuisMarchio03/golang_migration_system/internal/drivers"
 	"github.com/LuisMarchio03/golang_migration_system/internal/exec"
+	"github.com/LuisMarchio03/golang_migration_system/internal/source"
+	"github.com/LuisMarchio03/golang_migration_system/internal/statements"
 )
 
-// MigrationsDir é o diretório onde as migrações serão geradas e executadas
-var migrationsDir string // Variável global para armazenar o diretório de migrações
+// migrationsDir é o diretório usado pelas funções que dependem de SetMigrationsDir, protegido por migrationsDirMu
+var (
+	migrationsDirMu sync.RWMutex
+	migrationsDir   string
+)
 
 // SetMigrationsDir configura o diretório onde as migrações serão geradas e executadas
+//
+// Deprecated: o diretório é compartilhado por todo o processo; use um Migrator criado com WithDir.
 func SetMigrationsDir(dir string) {
+	migrationsDirMu.Lock()
+	defer migrationsDirMu.Unlock()
 	migrationsDir = dir
 }
 
 // GetMigrationsDir retorna o diretório atualmente configurado para as migrações
+//
+// Deprecated: use um Migrator criado com WithDir.
 func GetMigrationsDir() string {
+	migrationsDirMu.RLock()
+	defer migrationsDirMu.RUnlock()
 	return migrationsDir
 }
 
+// Migrator executa as operações do sistema de migrações (Up, Down, Goto, Status etc.) em um banco de dados,
+// com toda a configuração definida na criação com NewMigrator, sem variáveis globais. Vários Migrators, com
+// bancos, origens e tabelas de histórico diferentes, podem ser usados ao mesmo tempo
+type Migrator = exec.Migrator
+
+// Option configura um Migrator criado com NewMigrator
+type Option = exec.Option
+
+// Logger recebe as mensagens de andamento das migrações; é implementado por *log.Logger
+type Logger = exec.Logger
+
+// LockFunc obtém o lock de migração identificado por name e retorna a função que o libera
+type LockFunc = exec.LockFunc
+
+// NewMigrator cria um Migrator com as opções informadas. WithDB e WithSource (ou WithDir) são obrigatórias
+func NewMigrator(opts ...Option) (*Migrator, error) {
+	return exec.New(opts...)
+}
+
+// WithDB define a conexão com o banco de dados do Migrator
+func WithDB(db *sql.DB) Option {
+	return exec.WithDB(db)
+}
+
+// WithDriver define o Driver do banco de dados, necessário para os drivers registrados com Register que não
+// usam um dos drivers database/sql conhecidos
+func WithDriver(driver Driver) Option {
+	return exec.WithDriver(driver)
+}
+
+// WithDialect substitui o dialeto do driver do banco de dados
+func WithDialect(d Dialect) Option {
+	return exec.WithDialect(d)
+}
+
+// WithSource define a origem das migrações do Migrator
+func WithSource(src Source) Option {
+	return exec.WithSource(src)
+}
+
+// WithDir usa como origem os arquivos de migração do diretório, onde também são criadas as novas migrações
+func WithDir(dir string) Option {
+	return exec.WithDir(dir)
+}
+
+// WithLogger define onde são escritas as mensagens de andamento; com nil, são descartadas
+func WithLogger(logger Logger) Option {
+	return exec.WithLogger(logger)
+}
+
+// WithLock substitui o lock de migração do driver
+func WithLock(lock LockFunc) Option {
+	return exec.WithLock(lock)
+}
+
+// WithLockTimeout define o tempo máximo de espera pelo lock de migração
+func WithLockTimeout(timeout time.Duration) Option {
+	return exec.WithLockTimeout(timeout)
+}
+
+// WithTableName define o nome da tabela de histórico, que também identifica o lock de migração
+func WithTableName(name string) Option {
+	return exec.WithTableName(name)
+}
+
+// ErrLockTimeout é retornado quando o lock de migração não é obtido dentro do tempo limite
+var ErrLockTimeout = exec.ErrLockTimeout
+
+// DefaultLockTimeout é o tempo máximo padrão de espera pelo lock de migração de um Migrator
+const DefaultLockTimeout = exec.DefaultLockTimeout
+
+// SetLockTimeout configura o tempo máximo de espera das funções do pacote pelo lock de migração, que impede
+// que vários processos executem migrações ao mesmo tempo. Não altera os Migrators
+//
+// Deprecated: o valor é compartilhado por todo o processo; use um Migrator criado com WithLockTimeout.
+func SetLockTimeout(timeout time.Duration) {
+	exec.SetLockTimeout(timeout)
+}
+
+// GetLockTimeout retorna o tempo máximo de espera das funções do pacote pelo lock de migração
+//
+// Deprecated: use um Migrator criado com WithLockTimeout.
+func GetLockTimeout() time.Duration {
+	return exec.GetLockTimeout()
+}
+
 // Cfg representa a configuração do banco de dados
 type Cfg = config.Cfg
 
 // Schema representa um esquema de tabela
 type Schema = config.Schema
 
-// ConfigDB configura e retorna uma conexão com o banco de dados
+// Column representa uma coluna de tabela
+type Column = config.Column
+
+// Index representa um índice de tabela
+type Index = config.Index
+
+// UniqueConstraint representa uma restrição UNIQUE
+type UniqueConstraint = config.UniqueConstraint
+
+// ForeignKey representa uma chave estrangeira
+type ForeignKey = config.ForeignKey
+
+// Check representa uma restrição CHECK
+type Check = config.Check
+
+// DataType é o tipo lógico de uma coluna, traduzido para o tipo de cada banco de dados
+type DataType = config.DataType
+
+// Tipos lógicos de coluna
+const (
+	SmallInt  = config.SmallInt
+	Integer   = config.Integer
+	BigInt    = config.BigInt
+	Decimal   = config.Decimal
+	Float     = config.Float
+	Boolean   = config.Boolean
+	String    = config.String
+	Text      = config.Text
+	Date      = config.Date
+	Timestamp = config.Timestamp
+	Binary    = config.Binary
+	UUID      = config.UUID
+	JSON      = config.JSON
+)
+
+// Driver integra um banco de dados ao sistema de migrações: conexão, dialeto, lock de migração
+// e criação da tabela de histórico
+type Driver = drivers.Driver
+
+// Introspector é implementado, opcionalmente, pelos drivers que leem a estrutura das tabelas existentes,
+// usada por Introspect e DetectDrift
+type Introspector = drivers.Introspector
+
+// Dialect descreve as particularidades da linguagem SQL de um banco de dados
+type Dialect = dialect.Dialect
+
+// Constraint é uma restrição de tabela já escrita no dialeto, recebida pelos métodos AddConstraint e
+// DropConstraint de um Dialect
+type Constraint = dialect.Constraint
+
+// ConstraintKind identifica o tipo de uma Constraint
+type ConstraintKind = dialect.ConstraintKind
+
+// Tipos de Constraint
+const (
+	UniqueConstraintKind     = dialect.UniqueConstraint
+	CheckConstraintKind      = dialect.CheckConstraint
+	ForeignKeyConstraintKind = dialect.ForeignKeyConstraint
+)
+
+// SQLDriver implementa um Driver para qualquer banco com driver database/sql, a partir do nome do
+// driver e do dialeto; pode ser incorporado em outro tipo para substituir alguns dos métodos
+type SQLDriver = drivers.SQLDriver
+
+// Register registra um driver com o nome informado, tornando-o disponível em ConfigDB.
+// Permite integrar outros bancos de dados sem alterar este projeto
+func Register(name string, driver Driver) {
+	drivers.Register(name, driver)
+}
+
+// TableLock implementa o lock de migração com uma tabela, para drivers de bancos sem locks nomeados
+func TableLock(ctx context.Context, db *sql.DB, d Dialect, name string) (func() error, error) {
+	return drivers.TableLock(ctx, db, d, name)
+}
+
+// ConfigDB configura e retorna uma conexão com o banco de dados, usando o driver registrado com o nome informado
+func ConfigDB(dbDriver string, cfg Cfg) (*sql.DB, error) {
+	return exec.ConfigDB(dbDriver, cfg)
+}
+
+// ExecConfigDB configura e retorna uma conexão com o banco de dados, e define o diretório de migrações global
+//
+// Deprecated: use ConfigDB e um Migrator criado com WithDB e WithDir.
 func ExecConfigDB(dbDriver string, cfg config.Cfg, migrationsDir string) (*sql.DB, error) {
 	db, err := exec.ConfigDB(dbDriver, cfg)
 	if err != nil {
@@ -39,16 +229,130 @@ func ExecConfigDB(dbDriver string, cfg config.Cfg, migrationsDir string) (*sql.D
 	return db, nil
 }
 
-// GenerateMigration gera um arquivo de migração com as schemas fornecidas
+// ExecGenerateMigration gera um arquivo de migração com as schemas fornecidas, no diretório de migrações global
+//
+// Deprecated: use Migrator.GenerateMigration.
 func ExecGenerateMigration(schemas ...config.Schema) (string, error) {
-	migrationFileName, err := exec.GenerateMigration(migrationsDir, schemas...)
+	migrationFileName, err := exec.GenerateMigration(GetMigrationsDir(), schemas...)
 	if err != nil {
 		return "", err
 	}
 	return migrationFileName, nil
 }
 
-// RunMigrations executa todas as migrações encontradas no diretório especificado
+// ExecGenerateDiffMigration gera a migração com as diferenças entre a versão anterior dos schemas e a desejada,
+// no diretório de migrações configurado. Retorna o nome do arquivo up, os pontos que precisam de revisão manual
+// (como possíveis renomeações de colunas) e um possível erro, se houver.
+//
+// Deprecated: use Migrator.GenerateDiffMigration.
+func ExecGenerateDiffMigration(previous []Schema, desired []Schema) (string, []string, error) {
+	return exec.GenerateDiffMigration(GetMigrationsDir(), previous, desired)
+}
+
+// Introspect lê as tabelas existentes no banco de dados e retorna a estrutura de cada uma, sem as tabelas do
+// sistema de migrações. Com ExecGenerateMigration, gera a migração inicial de um banco de dados já existente.
+//
+// Deprecated: use Migrator.Introspect.
+func Introspect(db *sql.DB) ([]Schema, error) {
+	return exec.Introspect(db)
+}
+
+// Source é a origem das migrações: um diretório, um fs.FS, um pacote tar ou zip, um servidor HTTP ou uma lista
+// declarada no código. Outras origens podem ser integradas implementando esta interface
+type Source = source.Source
+
+// SourceMigration descreve uma migração disponível em uma Source
+type SourceMigration = source.Migration
+
+// SQLMigration é uma migração SQL declarada no código, usada com SliceSource
+type SQLMigration = source.SQLMigration
+
+// Manifest é o documento JSON que lista os arquivos de migração servidos por HTTP, usado com HTTPSource
+type Manifest = source.Manifest
+
+// DirSource retorna a Source dos arquivos de migração do diretório informado
+func DirSource(dir string) Source {
+	return source.Dir(dir)
+}
+
+// FSSource retorna a Source dos arquivos de migração da raiz de fsys
+func FSSource(fsys fs.FS) Source {
+	return source.FS(fsys)
+}
+
+// ZipSource retorna a Source dos arquivos de migração da raiz de um pacote zip
+func ZipSource(r io.ReaderAt, size int64) (Source, error) {
+	return source.Zip(r, size)
+}
+
+// TarSource retorna a Source dos arquivos de migração da raiz de um pacote tar
+func TarSource(r io.Reader) (Source, error) {
+	return source.Tar(r)
+}
+
+// HTTPTimeout é o tempo limite de cada requisição de HTTPSource quando nenhum cliente é informado
+const HTTPTimeout = source.HTTPTimeout
+
+// HTTPSource retorna a Source das migrações listadas no manifesto servido em manifestURL
+// Com client nil, cada requisição tem o tempo limite HTTPTimeout
+func HTTPSource(client *http.Client, manifestURL string) (Source, error) {
+	return source.HTTP(client, manifestURL)
+}
+
+// SliceSource retorna a Source das migrações SQL declaradas no código
+func SliceSource(migrations ...SQLMigration) (Source, error) {
+	return source.Slice(migrations...)
+}
+
+// MigrationFunc é uma migração escrita em Go, executada dentro de uma transação
+type MigrationFunc = exec.MigrationFunc
+
+// GoMigration é uma migração escrita em Go, configurada em um Migrator com WithGoMigrations
+type GoMigration = exec.GoMigration
+
+// WithGoMigrations inclui migrações escritas em Go, executadas apenas por este Migrator na ordem das versões
+// junto com as migrações SQL e registradas no mesmo histórico
+func WithGoMigrations(migrations ...GoMigration) Option {
+	return exec.WithGoMigrations(migrations...)
+}
+
+// RegisterMigration registra uma migração escrita em Go, executada pelas funções do pacote na ordem das versões
+// junto com as migrações SQL e registrada no mesmo histórico. Com down nil, a migração não pode ser revertida
+//
+// Deprecated: o registro é compartilhado por todo o processo; use um Migrator criado com WithGoMigrations.
+func RegisterMigration(version int64, up MigrationFunc, down MigrationFunc) {
+	exec.RegisterMigration(version, up, down)
+}
+
+// Drift é uma divergência entre a estrutura do banco de dados e a estrutura resultante das migrações
+type Drift = drift.Difference
+
+// DetectDrift compara a estrutura do banco de dados com a resultante das migrações do diretório, executadas
+// no banco vazio shadow, do mesmo tipo e descartável. Retorna as divergências, vazias quando o banco
+// corresponde às migrações
+//
+// Deprecated: use Migrator.DetectDrift.
+func DetectDrift(db *sql.DB, shadow *sql.DB, migrationsDir string) ([]Drift, error) {
+	return exec.DetectDrift(db, shadow, migrationsDir)
+}
+
+// DetectDriftFS compara a estrutura do banco de dados com a resultante das migrações da raiz de fsys
+//
+// Deprecated: use Migrator.DetectDrift.
+func DetectDriftFS(db *sql.DB, shadow *sql.DB, fsys fs.FS) ([]Drift, error) {
+	return exec.DetectDriftFS(db, shadow, fsys)
+}
+
+// DetectDriftFrom compara a estrutura do banco de dados com a resultante das migrações da origem src
+//
+// Deprecated: use Migrator.DetectDrift.
+func DetectDriftFrom(db *sql.DB, shadow *sql.DB, src Source) ([]Drift, error) {
+	return exec.DetectDriftFrom(db, shadow, src)
+}
+
+// ExecRunMigrations executa todas as migrações encontradas no diretório especificado
+//
+// Deprecated: use Migrator.Up.
 func ExecRunMigrations(db *sql.DB, migrationsDir string) error {
 	err := exec.RunMigrations(db, migrationsDir)
 	if err != nil {
@@ -56,3 +360,123 @@ func ExecRunMigrations(db *sql.DB, migrationsDir string) error {
 	}
 	return nil
 }
+
+// ExecRunMigrationsFS executa as migrações encontradas na raiz de fsys, como um diretório embutido no binário
+// com go:embed (use fs.Sub para usá-lo como raiz)
+//
+// Deprecated: use Migrator.Up, com WithSource(FSSource(fsys)).
+func ExecRunMigrationsFS(db *sql.DB, fsys fs.FS) error {
+	return exec.RunMigrationsFS(db, fsys)
+}
+
+// ExecRunMigrationsFrom executa as migrações pendentes da origem src
+//
+// Deprecated: use Migrator.Up.
+func ExecRunMigrationsFrom(db *sql.DB, src Source) error {
+	return exec.RunMigrationsFrom(db, src)
+}
+
+// Rollback reverte as últimas steps migrações aplicadas no banco de dados, em ordem inversa,
+// usando os arquivos .down.sql encontrados no diretório especificado
+//
+// Deprecated: use Migrator.Down.
+func Rollback(db *sql.DB, migrationsDir string, steps int) error {
+	return exec.Rollback(db, migrationsDir, steps)
+}
+
+// RollbackFS reverte as últimas steps migrações aplicadas, usando os arquivos .down.sql da raiz de fsys
+//
+// Deprecated: use Migrator.Down.
+func RollbackFS(db *sql.DB, fsys fs.FS, steps int) error {
+	return exec.RollbackFS(db, fsys, steps)
+}
+
+// RollbackFrom reverte as últimas steps migrações aplicadas, usando as reversões da origem src
+//
+// Deprecated: use Migrator.Down.
+func RollbackFrom(db *sql.DB, src Source, steps int) error {
+	return exec.RollbackFrom(db, src, steps)
+}
+
+// PlannedMigration descreve uma migração pendente e os comandos que seriam executados
+type PlannedMigration = exec.PlannedMigration
+
+// Statement é um comando de uma migração, com a linha do arquivo em que começa
+type Statement = statements.Statement
+
+// Plan retorna as migrações pendentes da origem src, na ordem de execução e com os comandos de cada uma,
+// sem executar nada
+//
+// Deprecated: use Migrator.Plan.
+func Plan(db *sql.DB, src Source) ([]PlannedMigration, error) {
+	return exec.Plan(db, src)
+}
+
+// MigrationStatus descreve a situação de uma migração no banco de dados
+type MigrationStatus = exec.MigrationStatus
+
+// MigrationState é a situação de uma migração: aplicada, pendente, sem arquivo, com arquivo alterado ou suja
+type MigrationState = exec.MigrationState
+
+// Situações de uma migração
+const (
+	StateApplied          = exec.StateApplied
+	StatePending          = exec.StatePending
+	StateMissingFile      = exec.StateMissingFile
+	StateChecksumMismatch = exec.StateChecksumMismatch
+	StateDirty            = exec.StateDirty
+)
+
+// Status combina as migrações da origem src com a tabela de histórico e retorna a situação de cada uma,
+// ordenadas pela versão
+//
+// Deprecated: use Migrator.Status.
+func Status(db *sql.DB, src Source) ([]MigrationStatus, error) {
+	return exec.StatusFrom(db, src)
+}
+
+// Goto leva o banco de dados exatamente à versão informada, revertendo as migrações aplicadas com versão maior
+// e aplicando as pendentes até ela. Antes de começar, verifica se a versão existe e se todas as migrações a
+// reverter possuem arquivo down. Com a versão zero, todas as migrações são revertidas
+//
+// Deprecated: use Migrator.Goto.
+func Goto(ctx context.Context, db *sql.DB, src Source, version int64) error {
+	return exec.GotoFrom(ctx, db, src, version)
+}
+
+// DirtyError indica que uma migração foi interrompida, ou falhou fora de uma transação, e o banco de dados pode
+// ter ficado parcialmente migrado. Nenhuma migração é executada até que a situação seja resolvida com Force
+type DirtyError = exec.DirtyError
+
+// Force registra o banco de dados como estando exatamente na versão informada, sem executar nenhuma migração.
+// Resolve um banco sujo depois da sua correção manual
+//
+// Deprecated: use Migrator.Force.
+func Force(db *sql.DB, src Source, version int64) error {
+	return exec.ForceFrom(db, src, version)
+}
+
+// ChecksumMismatchError indica que o arquivo de uma migração já aplicada foi alterado
+type ChecksumMismatchError = exec.ChecksumMismatchError
+
+// Repair atualiza os checksums registrados das migrações aplicadas com o conteúdo atual dos arquivos,
+// depois que as alterações feitas neles foram revisadas
+//
+// Deprecated: use Migrator.Repair.
+func Repair(db *sql.DB, migrationsDir string) error {
+	return exec.Repair(db, migrationsDir)
+}
+
+// RepairFS atualiza os checksums registrados das migrações aplicadas com o conteúdo atual dos arquivos da raiz de fsys
+//
+// Deprecated: use Migrator.Repair.
+func RepairFS(db *sql.DB, fsys fs.FS) error {
+	return exec.RepairFS(db, fsys)
+}
+
+// RepairFrom atualiza os checksums registrados das migrações aplicadas com o conteúdo atual da origem src
+//
+// Deprecated: use Migrator.Repair.
+func RepairFrom(db *sql.DB, src Source) error {
+	return exec.RepairFrom(db, src)
+}
@@ -1,26 +1,34 @@
 package golang_migration_system_test
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"embed"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/LuisMarchio03/golang_migration_system/internal/config"
 	golang_migration_system "github.com/LuisMarchio03/golang_migration_system/pkg"
 	"github.com/stretchr/testify/assert"
 )
 
+//go:embed testdata/migrations/*.sql
+var embeddedMigrations embed.FS
+
 func TestExecConfigDB(t *testing.T) {
-	// Configuração dos parâmetros do teste
-	dbDriver := "mysql"
+	// Configuração dos parâmetros do teste: SQLite em memória, sem depender de um servidor
+	dbDriver := "sqlite"
 	cfg := config.Cfg{
-		User:   "meu_app_user",
-		Passwd: "meu_app_password",
-		Net:    "tcp",
-		Addr:   "localhost:3308",
-		DBName: "meu_app_db",
+		Path: ":memory:",
 	}
-	migrationsDir := "."
+	migrationsDir := t.TempDir()
 
 	// Executa a função a ser testada
 	db, err := golang_migration_system.ExecConfigDB(dbDriver, cfg, migrationsDir)
@@ -33,12 +41,19 @@ func TestExecConfigDB(t *testing.T) {
 
 	// Fecha a conexão com o banco de dados no final do teste
 	defer db.Close()
+
+	// Verifica se o diretório de migrações foi definido
+	assert.Equal(t, migrationsDir, golang_migration_system.GetMigrationsDir())
 }
 
 func TestExecGenerateMigration(t *testing.T) {
+	// Define um diretório temporário para as migrações
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+
 	// Cria um exemplo de schema para teste
 	schema := config.Schema{
-		DbType:    "mysql",
+		DbType:    "sqlite",
 		TableName: "test",
 		Fields: map[string]string{
 			"id":       "INT AUTO_INCREMENT PRIMARY KEY",
@@ -55,34 +70,955 @@ func TestExecGenerateMigration(t *testing.T) {
 
 	// Verifica se o nome do arquivo de migração foi retornado corretamente
 	assert.NotEmpty(t, migrationFileName, "Nome do arquivo de migração não está definido")
+
+	// Verifica se a coluna auto incremento foi adaptada ao SQLite
+	content, err := os.ReadFile(filepath.Join(migrationsDir, migrationFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "id INTEGER PRIMARY KEY AUTOINCREMENT")
+
+	// Uma segunda migração gerada no mesmo segundo não sobrescreve a primeira
+	schema.TableName = "other"
+	otherFileName, err := golang_migration_system.ExecGenerateMigration(schema)
+	assert.NoError(t, err)
+	assert.NotEqual(t, migrationFileName, otherFileName)
+	content, err = os.ReadFile(filepath.Join(migrationsDir, migrationFileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "CREATE TABLE IF NOT EXISTS test (")
 }
 
 func TestExecRunMigrations(t *testing.T) {
-	cfg := config.Cfg{
-		User:   "meu_app_user",
-		Passwd: "meu_app_password",
-		Net:    "tcp",
-		Addr:   "localhost:3308",
-		DBName: "meu_app_db",
-	}
-	connString := fmt.Sprintf("%s:%s@%s(%s)/%s",
-		cfg.User,
-		cfg.Passwd,
-		cfg.Net,
-		cfg.Addr,
-		cfg.DBName,
-	)
-
-	// Simula a conexão com o banco de dados (pode ser feita com um banco de dados de teste)
-	db, _ := sql.Open("mysql", connString)
+	// Banco SQLite em memória
+	migrationsDir := t.TempDir()
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, migrationsDir)
+	assert.NoError(t, err, "Erro ao configurar o banco de dados")
 	defer db.Close()
 
-	// Define o diretório de migrações para teste
-	migrationsDir := "."
+	// Gera uma migração no diretório de migrações para teste
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Fields: map[string]string{
+			"id":       "INT AUTO_INCREMENT PRIMARY KEY",
+			"username": "VARCHAR(50) NOT NULL",
+		},
+	})
+	assert.NoError(t, err, "Erro ao gerar a migração")
 
 	// Executa a função a ser testada
-	err := golang_migration_system.ExecRunMigrations(db, migrationsDir)
+	err = golang_migration_system.ExecRunMigrations(db, migrationsDir)
 
 	// Verifica se não houve erro na execução das migrações
 	assert.NoError(t, err, "Erro ao executar as migrações")
+
+	// Verifica se a tabela foi criada
+	_, err = db.Exec("INSERT INTO users (username) VALUES ('luis')")
+	assert.NoError(t, err, "A tabela users não foi criada")
+}
+
+func TestExecRunMigrationsTypedColumns(t *testing.T) {
+	migrationsDir := t.TempDir()
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, migrationsDir)
+	assert.NoError(t, err, "Erro ao configurar o banco de dados")
+	defer db.Close()
+
+	// Gera a migração a partir de colunas tipadas, sem tipos específicos do banco
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Columns: []golang_migration_system.Column{
+			{Name: "id", DataType: golang_migration_system.BigInt, PrimaryKey: true, AutoIncrement: true},
+			{Name: "username", DataType: golang_migration_system.String, Length: 50, Unique: true},
+			{Name: "active", DataType: golang_migration_system.Boolean, Default: "1"},
+			{Name: "bio", DataType: golang_migration_system.Text, Nullable: true},
+		},
+	})
+	assert.NoError(t, err, "Erro ao gerar a migração")
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir), "Erro ao executar as migrações")
+
+	// O id é gerado, active recebe o valor padrão e as restrições NOT NULL e UNIQUE são respeitadas
+	_, err = db.Exec("INSERT INTO users (username) VALUES ('luis')")
+	assert.NoError(t, err)
+	var id, active int
+	assert.NoError(t, db.QueryRow("SELECT id, active FROM users WHERE username = 'luis'").Scan(&id, &active))
+	assert.Equal(t, 1, id)
+	assert.Equal(t, 1, active)
+
+	_, err = db.Exec("INSERT INTO users (username) VALUES ('luis')")
+	assert.Error(t, err, "A coluna username deveria ser UNIQUE")
+	_, err = db.Exec("INSERT INTO users (bio) VALUES ('sem nome')")
+	assert.Error(t, err, "A coluna username deveria ser NOT NULL")
+}
+
+func TestExecRunMigrationsSkipsApplied(t *testing.T) {
+	// Banco SQLite temporário e diretório de migrações com um único arquivo
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrationsDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.sql"),
+		[]byte("CREATE TABLE users (id INTEGER PRIMARY KEY, username VARCHAR(50));"), 0644)
+	assert.NoError(t, err)
+
+	// A primeira execução aplica a migração e a segunda não deve repeti-la
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir), "A migração foi executada novamente")
+
+	// Verifica o registro na tabela de histórico
+	var name string
+	var success int
+	err = db.QueryRow("SELECT name, success FROM schema_migrations WHERE version = 20240101000000").Scan(&name, &success)
+	assert.NoError(t, err)
+	assert.Equal(t, "migration_20240101000000.sql", name)
+	assert.Equal(t, 1, success)
+}
+
+func TestRollback(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Gera a migração no diretório temporário e aplica
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Fields:    map[string]string{"id": "INTEGER PRIMARY KEY"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// Reverte a migração e verifica que a tabela e o registro no histórico foram removidos
+	assert.NoError(t, golang_migration_system.Rollback(db, migrationsDir, 1))
+
+	_, err = db.Exec("SELECT id FROM users")
+	assert.Error(t, err, "A tabela users não foi removida")
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestExecGenerateMigrationConstraints(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// A tabela orders é declarada antes de customers, que ela referencia
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+	_, err = golang_migration_system.ExecGenerateMigration(
+		config.Schema{
+			DbType:    "sqlite",
+			TableName: "orders",
+			Columns: []golang_migration_system.Column{
+				{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true},
+				{Name: "customer_id", DataType: golang_migration_system.Integer},
+				{Name: "total", DataType: golang_migration_system.Decimal, Precision: 10, Scale: 2},
+			},
+			Indexes:     []golang_migration_system.Index{{Columns: []string{"customer_id"}}},
+			ForeignKeys: []golang_migration_system.ForeignKey{{Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}, OnDelete: "CASCADE"}},
+			Checks:      []golang_migration_system.Check{{Expression: "total >= 0"}},
+		},
+		config.Schema{
+			DbType:            "sqlite",
+			TableName:         "customers",
+			Columns:           []golang_migration_system.Column{{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true}, {Name: "email", DataType: golang_migration_system.String}},
+			UniqueConstraints: []golang_migration_system.UniqueConstraint{{Columns: []string{"email"}}},
+		},
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// As restrições UNIQUE, CHECK e a chave estrangeira são aplicadas
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO customers (id, email) VALUES (1, 'luis@example.com')")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO customers (id, email) VALUES (2, 'luis@example.com')")
+	assert.Error(t, err, "A restrição UNIQUE não foi criada")
+	_, err = db.Exec("INSERT INTO orders (id, customer_id, total) VALUES (1, 1, -1)")
+	assert.Error(t, err, "A restrição CHECK não foi criada")
+	_, err = db.Exec("INSERT INTO orders (id, customer_id, total) VALUES (1, 99, 10)")
+	assert.Error(t, err, "A chave estrangeira não foi criada")
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_orders_customer_id'").Scan(&count))
+	assert.Equal(t, 1, count, "O índice não foi criado")
+
+	// A reversão remove o índice e as tabelas
+	assert.NoError(t, golang_migration_system.Rollback(db, migrationsDir, 1))
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name IN ('orders', 'customers', 'idx_orders_customer_id')").Scan(&count))
+	assert.Equal(t, 0, count)
+}
+
+func TestExecGenerateDiffMigration(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Versão anterior da tabela, já existente no banco
+	previous := config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Columns: []golang_migration_system.Column{
+			{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true},
+			{Name: "name", DataType: golang_migration_system.String, Length: 50},
+		},
+	}
+	_, err = db.Exec("CREATE TABLE users (id INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL)")
+	assert.NoError(t, err)
+
+	// Renomeia name e acrescenta email com índice
+	desired := previous
+	desired.Columns = []golang_migration_system.Column{
+		{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true},
+		{Name: "full_name", RenamedFrom: "name", DataType: golang_migration_system.String, Length: 50},
+		{Name: "email", DataType: golang_migration_system.String, Length: 100, Nullable: true},
+	}
+	desired.Indexes = []golang_migration_system.Index{{Columns: []string{"email"}}}
+
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+	_, warnings, err := golang_migration_system.ExecGenerateDiffMigration([]golang_migration_system.Schema{previous}, []golang_migration_system.Schema{desired})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	_, err = db.Exec("INSERT INTO users (id, full_name, email) VALUES (1, 'Luis', 'luis@example.com')")
+	assert.NoError(t, err, "As colunas não foram alteradas")
+
+	// A reversão volta à versão anterior, preservando os dados da coluna renomeada
+	assert.NoError(t, golang_migration_system.Rollback(db, migrationsDir, 1))
+	var name string
+	assert.NoError(t, db.QueryRow("SELECT name FROM users WHERE id = 1").Scan(&name))
+	assert.Equal(t, "Luis", name)
+	_, err = db.Exec("SELECT email FROM users")
+	assert.Error(t, err, "A coluna email não foi removida")
+
+	// Schemas iguais não geram migração
+	_, _, err = golang_migration_system.ExecGenerateDiffMigration([]golang_migration_system.Schema{previous}, []golang_migration_system.Schema{previous})
+	assert.Error(t, err)
+}
+
+func TestExecRunMigrationsRollsBackFailedMigration(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// A segunda instrução falha, pois a tabela missing não existe
+	migrationsDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.up.sql"),
+		[]byte("CREATE TABLE users (id INTEGER PRIMARY KEY);\nINSERT INTO missing VALUES (1);"), 0644)
+	assert.NoError(t, err)
+
+	// O erro indica o comando que falhou e a linha em que ele começa
+	err = golang_migration_system.ExecRunMigrations(db, migrationsDir)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "comando 2 (linha 2)")
+	}
+
+	// A transação deve ter desfeito a criação da tabela e a falha deve estar registrada no histórico
+	_, err = db.Exec("SELECT id FROM users")
+	assert.Error(t, err, "A tabela users não foi removida pelo rollback")
+
+	var success int
+	assert.NoError(t, db.QueryRow("SELECT success FROM schema_migrations WHERE version = 20240101000000").Scan(&success))
+	assert.Equal(t, 0, success)
+}
+
+func TestExecRunMigrationsWaitsForLock(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Simula outro processo detendo o lock de migração por mais uma hora
+	_, err = db.Exec("CREATE TABLE schema_migrations_lock (id INTEGER NOT NULL PRIMARY KEY, locked_by VARCHAR(255) NOT NULL, expires_at BIGINT NOT NULL)")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO schema_migrations_lock VALUES (1, 'outro-processo', ?)", time.Now().Add(time.Hour).UnixMilli())
+	assert.NoError(t, err)
+
+	defaultTimeout := golang_migration_system.GetLockTimeout()
+	golang_migration_system.SetLockTimeout(200 * time.Millisecond)
+	defer golang_migration_system.SetLockTimeout(defaultTimeout)
+
+	// Sem o lock, a execução deve esperar e falhar após o tempo limite
+	err = golang_migration_system.ExecRunMigrations(db, t.TempDir())
+	assert.ErrorIs(t, err, golang_migration_system.ErrLockTimeout, "As migrações foram executadas sem o lock")
+
+	// Com o lock liberado, a execução prossegue normalmente
+	_, err = db.Exec("DELETE FROM schema_migrations_lock")
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, t.TempDir()))
+}
+
+func TestMigratorIgnoresPackageLockTimeout(t *testing.T) {
+	db, err := golang_migration_system.ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	defaultTimeout := golang_migration_system.GetLockTimeout()
+	golang_migration_system.SetLockTimeout(time.Millisecond)
+	defer golang_migration_system.SetLockTimeout(defaultTimeout)
+
+	// O Migrator aguarda o lock pelo tempo padrão, e não pelo valor configurado no pacote
+	var wait time.Duration
+	lock := func(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+		deadline, _ := ctx.Deadline()
+		wait = time.Until(deadline)
+		return func() error { return nil }, nil
+	}
+	m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(t.TempDir()),
+		golang_migration_system.WithLock(lock), golang_migration_system.WithLogger(nil))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Up(context.Background()))
+	assert.Greater(t, wait, golang_migration_system.DefaultLockTimeout-time.Minute)
+}
+
+func TestLockReturnsInsertErrors(t *testing.T) {
+	db, err := golang_migration_system.ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Uma tabela de lock com uma coluna obrigatória a mais faz a inserção falhar sem violação de chave primária
+	_, err = db.Exec("CREATE TABLE schema_migrations_lock (id INTEGER NOT NULL PRIMARY KEY, locked_by VARCHAR(255) NOT NULL, expires_at BIGINT NOT NULL, extra TEXT NOT NULL)")
+	assert.NoError(t, err)
+
+	m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(t.TempDir()),
+		golang_migration_system.WithLockTimeout(time.Minute), golang_migration_system.WithLogger(nil))
+	assert.NoError(t, err)
+
+	// O erro é retornado imediatamente, sem aguardar o tempo limite do lock
+	started := time.Now()
+	err = m.Up(context.Background())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, golang_migration_system.ErrLockTimeout)
+	assert.Less(t, time.Since(started), 10*time.Second)
+}
+
+func TestExecRunMigrationsDetectsChangedFile(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrationsDir := t.TempDir()
+	migrationPath := filepath.Join(migrationsDir, "migration_20240101000000.up.sql")
+	assert.NoError(t, os.WriteFile(migrationPath, []byte("CREATE TABLE users (id INTEGER PRIMARY KEY);"), 0644))
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// Altera o arquivo de uma migração já aplicada
+	assert.NoError(t, os.WriteFile(migrationPath, []byte("CREATE TABLE users (id BIGINT PRIMARY KEY);"), 0644))
+
+	err = golang_migration_system.ExecRunMigrations(db, migrationsDir)
+	var mismatch *golang_migration_system.ChecksumMismatchError
+	if assert.ErrorAs(t, err, &mismatch) {
+		assert.Equal(t, "migration_20240101000000.up.sql", mismatch.Name)
+		assert.NotEqual(t, mismatch.Expected, mismatch.Actual)
+	}
+
+	// Depois do repair, a alteração é aceita
+	assert.NoError(t, golang_migration_system.Repair(db, migrationsDir))
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+}
+
+// customDriver simula um banco de dados integrado por outro time: usa o SQLite, mas com o próprio dialeto
+type customDriver struct {
+	golang_migration_system.SQLDriver
+}
+
+func (customDriver) Open(cfg config.Cfg) (*sql.DB, error) {
+	return sql.Open("sqlite3", cfg.DSN)
+}
+
+func TestRegisterCustomDriver(t *testing.T) {
+	golang_migration_system.Register("custom", customDriver{})
+
+	// O driver registrado deve estar disponível em ExecConfigDB, sem diferenciar maiúsculas de minúsculas
+	db, err := golang_migration_system.ExecConfigDB("Custom", config.Cfg{DSN: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrationsDir := t.TempDir()
+	err = os.WriteFile(filepath.Join(migrationsDir, "migration_20240101000000.up.sql"),
+		[]byte("CREATE TABLE users (id INTEGER PRIMARY KEY);"), 0644)
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// O lock do SQLDriver usa a tabela de lock, criada na primeira execução
+	_, err = db.Exec("SELECT id FROM schema_migrations_lock")
+	assert.NoError(t, err)
+
+	_, err = golang_migration_system.ExecConfigDB("unknown", config.Cfg{}, ".")
+	assert.Error(t, err, "Um driver não registrado foi aceito")
+}
+
+// lockingDriver registra os locks obtidos, para verificar qual driver o Migrator usa
+type lockingDriver struct {
+	customDriver
+	locks *[]string
+}
+
+func (d lockingDriver) Lock(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+	*d.locks = append(*d.locks, name)
+	return func() error { return nil }, nil
+}
+
+func TestMigratorWithDriver(t *testing.T) {
+	var locks []string
+	driver := lockingDriver{locks: &locks}
+	db, err := driver.Open(config.Cfg{DSN: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// A conexão não guarda o driver: o Migrator usa o driver informado em WithDriver
+	m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(t.TempDir()),
+		golang_migration_system.WithDriver(driver), golang_migration_system.WithLogger(nil))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Up(context.Background()))
+	assert.Equal(t, []string{"schema_migrations"}, locks)
+
+	// O driver não implementa Introspector: a introspecção é recusada com um erro claro
+	_, err = m.Introspect(context.Background())
+	assert.EqualError(t, err, "Erro ao ler a estrutura do banco de dados: O driver do banco de dados generic não suporta introspecção")
+}
+
+// reviewedDialect é um dialeto escrito fora do módulo, apenas com os tipos do pacote: marca as restrições UNIQUE
+// para revisão
+type reviewedDialect struct {
+	golang_migration_system.Dialect
+}
+
+func (d reviewedDialect) AddConstraint(table string, constraint golang_migration_system.Constraint) string {
+	statement := d.Dialect.AddConstraint(table, constraint)
+	if constraint.Kind == golang_migration_system.UniqueConstraintKind {
+		statement = "-- revisar\n" + statement
+	}
+	return statement
+}
+
+func TestCustomDialect(t *testing.T) {
+	golang_migration_system.Register("reviewed", golang_migration_system.SQLDriver{
+		DriverName: "sqlite3",
+		SQLDialect: reviewedDialect{golang_migration_system.SQLDriver{}.Dialect()},
+	})
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+
+	fileName, err := golang_migration_system.ExecGenerateMigration(golang_migration_system.Schema{
+		DbType:            "reviewed",
+		TableName:         "users",
+		Fields:            map[string]string{"email": "VARCHAR(100)"},
+		UniqueConstraints: []golang_migration_system.UniqueConstraint{{Columns: []string{"email"}}},
+	})
+	assert.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(migrationsDir, fileName))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "-- revisar\nALTER TABLE")
+}
+
+func TestExecGenerateMigrationSQLServer(t *testing.T) {
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+
+	migrationFileName, err := golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlserver",
+		TableName: "users",
+		Fields:    map[string]string{"id": "INT AUTO_INCREMENT PRIMARY KEY"},
+	})
+	assert.NoError(t, err)
+
+	// O SQL Server usa guardas com OBJECT_ID, em vez de IF NOT EXISTS, e lotes separados por GO
+	up, err := os.ReadFile(filepath.Join(migrationsDir, migrationFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, "IF OBJECT_ID(N'users', N'U') IS NULL\nCREATE TABLE users (\n    id INT IDENTITY(1,1) PRIMARY KEY\n);\nGO\n\n", string(up))
+
+	down, err := os.ReadFile(filepath.Join(migrationsDir, strings.Replace(migrationFileName, ".up.sql", ".down.sql", 1)))
+	assert.NoError(t, err)
+	assert.Equal(t, "IF OBJECT_ID(N'users', N'U') IS NOT NULL\n    DROP TABLE users;\nGO\n", string(down))
+}
+
+func TestExecGenerateMigrationKeepsEveryTable(t *testing.T) {
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+
+	// Com várias tabelas no Firebird, todas devem estar no arquivo gerado, na ordem informada
+	migrationFileName, err := golang_migration_system.ExecGenerateMigration(
+		config.Schema{DbType: "FirebirdSql", TableName: "users", Fields: map[string]string{"id": "INTEGER NOT NULL PRIMARY KEY"}},
+		config.Schema{DbType: "FirebirdSql", TableName: "posts", Fields: map[string]string{"id": "INTEGER NOT NULL PRIMARY KEY"}},
+	)
+	assert.NoError(t, err)
+
+	up, err := os.ReadFile(filepath.Join(migrationsDir, migrationFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE users (\n    id INTEGER NOT NULL PRIMARY KEY\n);\n\nCREATE TABLE posts (\n    id INTEGER NOT NULL PRIMARY KEY\n);\n\n", string(up))
+
+	// Um DbType desconhecido é rejeitado
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{DbType: "unknown", TableName: "users"})
+	assert.Error(t, err)
+}
+
+func TestIntrospect(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Tipos que o SQLite guarda como foram gerados; os demais, como String e Boolean, são lidos como TEXT e INTEGER
+	customers := config.Schema{
+		DbType:    "sqlite",
+		TableName: "customers",
+		Columns: []golang_migration_system.Column{
+			{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true, AutoIncrement: true},
+			{Name: "email", DataType: golang_migration_system.Text, Unique: true},
+			{Name: "created_at", DataType: golang_migration_system.Timestamp, Default: "CURRENT_TIMESTAMP"},
+		},
+	}
+	orders := config.Schema{
+		DbType:    "sqlite",
+		TableName: "orders",
+		Columns: []golang_migration_system.Column{
+			{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true},
+			{Name: "customer_id", DataType: golang_migration_system.Integer},
+			{Name: "total", DataType: golang_migration_system.Decimal},
+			{Name: "notes", DataType: golang_migration_system.Text, Nullable: true},
+		},
+		Indexes:     []golang_migration_system.Index{{Columns: []string{"customer_id", "total"}}},
+		ForeignKeys: []golang_migration_system.ForeignKey{{Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}, OnDelete: "CASCADE"}},
+	}
+
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+	_, err = golang_migration_system.ExecGenerateMigration(customers, orders)
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// As tabelas são lidas em ordem alfabética, sem as tabelas do sistema de migrações
+	schemas, err := golang_migration_system.Introspect(db)
+	assert.NoError(t, err)
+	if assert.Len(t, schemas, 2) {
+		assert.Equal(t, "customers", schemas[0].TableName)
+		assert.Equal(t, "orders", schemas[1].TableName)
+	}
+
+	// A estrutura lida é igual à declarada: não há diferenças para gerar uma migração
+	_, _, err = golang_migration_system.ExecGenerateDiffMigration(schemas, []golang_migration_system.Schema{customers, orders})
+	assert.Error(t, err)
+}
+
+func TestDetectDrift(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrationsDir := t.TempDir()
+	golang_migration_system.SetMigrationsDir(migrationsDir)
+	_, err = golang_migration_system.ExecGenerateMigration(config.Schema{
+		DbType:    "sqlite",
+		TableName: "users",
+		Columns: []golang_migration_system.Column{
+			{Name: "id", DataType: golang_migration_system.Integer, PrimaryKey: true},
+			{Name: "name", DataType: golang_migration_system.Text},
+		},
+		Indexes: []golang_migration_system.Index{{Columns: []string{"name"}}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrations(db, migrationsDir))
+
+	// Logo após as migrações, não há divergências
+	shadow, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, ".")
+	assert.NoError(t, err)
+	differences, err := golang_migration_system.DetectDrift(db, shadow, migrationsDir)
+	assert.NoError(t, err)
+	assert.Empty(t, differences)
+	shadow.Close()
+
+	// Alterações manuais no banco de dados são apontadas
+	for _, statement := range []string{"ALTER TABLE users ADD COLUMN phone TEXT", "DROP INDEX idx_users_name", "CREATE TABLE audit (id INTEGER)"} {
+		_, err = db.Exec(statement)
+		assert.NoError(t, err)
+	}
+	shadow, err = golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, ".")
+	assert.NoError(t, err)
+	defer shadow.Close()
+	differences, err = golang_migration_system.DetectDrift(db, shadow, migrationsDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []golang_migration_system.Drift{
+		{Kind: "extra-table", Table: "audit"},
+		{Kind: "extra-column", Table: "users", Name: "phone", Actual: "TEXT"},
+		{Kind: "missing-index", Table: "users", Name: "idx_users_name", Expected: "(name)"},
+	}, differences)
+
+	// O banco de comparação deve estar vazio
+	_, err = golang_migration_system.DetectDrift(db, db, migrationsDir)
+	assert.Error(t, err)
+
+	// Um banco sem a tabela de histórico está na versão zero, e a tabela não é criada pela comparação
+	live, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "live.db")}, ".")
+	assert.NoError(t, err)
+	defer live.Close()
+	_, err = live.Exec("CREATE TABLE audit (id INTEGER)")
+	assert.NoError(t, err)
+	empty, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: ":memory:"}, ".")
+	assert.NoError(t, err)
+	defer empty.Close()
+	differences, err = golang_migration_system.DetectDrift(live, empty, migrationsDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []golang_migration_system.Drift{{Kind: "extra-table", Table: "audit"}}, differences)
+	_, err = live.Exec("SELECT version FROM schema_migrations")
+	assert.Error(t, err, "A comparação não deve criar a tabela de histórico")
+}
+
+func TestExecRunMigrationsFS(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// As migrações embutidas no binário são lidas a partir do diretório migrations
+	migrations, err := fs.Sub(embeddedMigrations, "testdata/migrations")
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrationsFS(db, migrations))
+	_, err = db.Exec("INSERT INTO embedded_users (id, name) VALUES (1, 'Luis')")
+	assert.NoError(t, err, "A migração embutida não foi executada")
+
+	// A reversão usa o arquivo down embutido
+	assert.NoError(t, golang_migration_system.RollbackFS(db, migrations, 1))
+	_, err = db.Exec("SELECT id FROM embedded_users")
+	assert.Error(t, err, "A tabela não foi removida")
+
+	// Sem o fs.Sub, a raiz não contém migrações e nada é executado
+	assert.NoError(t, golang_migration_system.ExecRunMigrationsFS(db, embeddedMigrations))
+	_, err = db.Exec("SELECT id FROM embedded_users")
+	assert.Error(t, err)
+}
+
+func TestExecRunMigrationsFrom(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Migrações declaradas no código, fora de ordem
+	src, err := golang_migration_system.SliceSource(
+		golang_migration_system.SQLMigration{Version: 2, Name: "posts", Up: "CREATE TABLE posts (id INTEGER);", Down: "DROP TABLE posts;"},
+		golang_migration_system.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);", Down: "DROP TABLE users;"},
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrationsFrom(db, src))
+
+	var names []string
+	rows, err := db.Query("SELECT name FROM schema_migrations ORDER BY version")
+	assert.NoError(t, err)
+	for rows.Next() {
+		var name string
+		assert.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	rows.Close()
+	assert.Equal(t, []string{"users", "posts"}, names)
+
+	// A reversão usa o Down declarado
+	assert.NoError(t, golang_migration_system.RollbackFrom(db, src, 1))
+	_, err = db.Exec("SELECT id FROM posts")
+	assert.Error(t, err, "A tabela não foi removida")
+	_, err = db.Exec("SELECT id FROM users")
+	assert.NoError(t, err)
+}
+
+func TestGoto(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	migrations := []golang_migration_system.SQLMigration{
+		{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);", Down: "DROP TABLE users;"},
+		{Version: 2, Name: "posts", Up: "CREATE TABLE posts (id INTEGER);", Down: "DROP TABLE posts;"},
+		{Version: 3, Name: "tags", Up: "CREATE TABLE tags (id INTEGER);"},
+	}
+	src, err := golang_migration_system.SliceSource(migrations...)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	current := func() int64 {
+		status, err := golang_migration_system.Status(db, src)
+		assert.NoError(t, err)
+		var applied int64
+		for _, s := range status {
+			if s.State == golang_migration_system.StateApplied {
+				applied = s.Version
+			}
+		}
+		return applied
+	}
+
+	// Para cima, até a versão informada
+	assert.NoError(t, golang_migration_system.Goto(ctx, db, src, 2))
+	assert.Equal(t, int64(2), current())
+	assert.NoError(t, golang_migration_system.Goto(ctx, db, src, 3))
+	assert.Equal(t, int64(3), current())
+
+	// A versão 3 não possui down: nada é revertido
+	err = golang_migration_system.Goto(ctx, db, src, 1)
+	assert.EqualError(t, err, "A migração tags não possui arquivo down para ser revertida")
+	assert.Equal(t, int64(3), current())
+
+	// Versões inexistentes são rejeitadas antes de executar qualquer migração
+	assert.EqualError(t, golang_migration_system.Goto(ctx, db, src, 99), "A versão 99 não existe nas migrações")
+
+	// Com o down da versão 3, o banco volta à versão 1
+	migrations[2].Down = "DROP TABLE tags;"
+	src, err = golang_migration_system.SliceSource(migrations...)
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.Goto(ctx, db, src, 1))
+	assert.Equal(t, int64(1), current())
+	_, err = db.Exec("SELECT id FROM posts")
+	assert.Error(t, err, "A tabela posts não foi removida")
+
+	// Um contexto cancelado interrompe a execução
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, golang_migration_system.Goto(canceled, db, src, 3))
+	assert.Equal(t, int64(1), current())
+
+	// A versão zero reverte todas as migrações
+	assert.NoError(t, golang_migration_system.Goto(ctx, db, src, 0))
+	assert.Equal(t, int64(0), current())
+}
+
+func TestExecRunMigrationsReportsHistoryErrors(t *testing.T) {
+	db, err := golang_migration_system.ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// A migração falha depois de remover a tabela de histórico, que então não registra a falha
+	src, err := golang_migration_system.SliceSource(golang_migration_system.SQLMigration{
+		Version: 1, Name: "broken", Up: "-- migrate:no-transaction\nDROP TABLE schema_migrations;\nINSERT INTO missing VALUES (1);",
+	})
+	assert.NoError(t, err)
+	m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithSource(src),
+		golang_migration_system.WithLogger(nil))
+	assert.NoError(t, err)
+	err = m.Up(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no such table: missing")
+		assert.Contains(t, err.Error(), "e ao registrar a falha no histórico")
+	}
+}
+
+func TestExecRunMigrationsRefusesDirtyDatabase(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	// Sem transação, a falha do segundo comando deixa a tabela users criada e o banco sujo
+	src, err := golang_migration_system.SliceSource(
+		golang_migration_system.SQLMigration{Version: 1, Name: "users", Up: "-- migrate:no-transaction\nCREATE TABLE users (id INTEGER);\nINSERT INTO missing VALUES (1);"},
+		golang_migration_system.SQLMigration{Version: 2, Name: "posts", Up: "CREATE TABLE posts (id INTEGER);"},
+	)
+	assert.NoError(t, err)
+	assert.Error(t, golang_migration_system.ExecRunMigrationsFrom(db, src))
+	_, err = db.Exec("SELECT id FROM users")
+	assert.NoError(t, err)
+
+	// Enquanto o banco estiver sujo, nenhuma migração é executada
+	err = golang_migration_system.ExecRunMigrationsFrom(db, src)
+	var dirty *golang_migration_system.DirtyError
+	if assert.True(t, errors.As(err, &dirty), "erro inesperado: %v", err) {
+		assert.Equal(t, int64(1), dirty.Version)
+	}
+	_, err = db.Exec("SELECT id FROM posts")
+	assert.Error(t, err, "A migração posts não deveria ter sido executada")
+
+	status, err := golang_migration_system.Status(db, src)
+	assert.NoError(t, err)
+	assert.Equal(t, golang_migration_system.StateDirty, status[0].State)
+
+	// Depois da correção manual, o force resolve a situação e as migrações seguintes são executadas
+	_, err = db.Exec("DROP TABLE users")
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.Force(db, src, 0))
+	_, err = db.Exec("CREATE TABLE missing (id INTEGER)")
+	assert.NoError(t, err)
+	assert.NoError(t, golang_migration_system.ExecRunMigrationsFrom(db, src))
+
+	status, err = golang_migration_system.Status(db, src)
+	assert.NoError(t, err)
+	assert.Equal(t, golang_migration_system.StateApplied, status[0].State)
+	assert.Equal(t, golang_migration_system.StateApplied, status[1].State)
+}
+
+func TestPlanDoesNotChangeHistory(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+
+	// Uma tabela de histórico criada antes das colunas checksum e dirty
+	_, err = db.Exec("CREATE TABLE schema_migrations (version INTEGER, name TEXT, applied_at TIMESTAMP, execution_time INTEGER, success INTEGER)")
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT INTO schema_migrations VALUES (1, 'migration_1_users.up.sql', CURRENT_TIMESTAMP, 3, 1)")
+	assert.NoError(t, err)
+	src, err := golang_migration_system.SliceSource(
+		golang_migration_system.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);"},
+		golang_migration_system.SQLMigration{Version: 2, Name: "posts", Up: "CREATE TABLE posts (id INTEGER);"},
+	)
+	assert.NoError(t, err)
+
+	// O plano lê as colunas ausentes como vazias, sem incluí-las na tabela
+	plan, err := golang_migration_system.Plan(db, src)
+	assert.NoError(t, err)
+	if assert.Len(t, plan, 1) {
+		assert.Equal(t, int64(2), plan[0].Version)
+	}
+	_, err = db.Exec("SELECT checksum FROM schema_migrations")
+	assert.Error(t, err, "O plano não deve alterar a tabela de histórico")
+
+	// Com a conexão fechada, o erro é retornado em vez de um histórico vazio
+	assert.NoError(t, db.Close())
+	_, err = golang_migration_system.Plan(db, src)
+	assert.Error(t, err)
+}
+
+func TestStatusReturnsConnectionErrors(t *testing.T) {
+	db, err := golang_migration_system.ExecConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")}, ".")
+	assert.NoError(t, err)
+	src, err := golang_migration_system.SliceSource(
+		golang_migration_system.SQLMigration{Version: 1, Name: "users", Up: "CREATE TABLE users (id INTEGER);"},
+	)
+	assert.NoError(t, err)
+	m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithSource(src))
+	assert.NoError(t, err)
+
+	// Sem a tabela de histórico, todas as migrações estão pendentes
+	status, err := m.Status(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, status, 1) {
+		assert.Equal(t, golang_migration_system.StatePending, status[0].State)
+	}
+
+	// Com a conexão fechada, o erro é retornado em vez de migrações pendentes
+	assert.NoError(t, db.Close())
+	_, err = m.Status(context.Background())
+	assert.Error(t, err)
+	_, err = golang_migration_system.Status(db, src)
+	assert.Error(t, err)
+}
+
+func TestMigrator(t *testing.T) {
+	// Opções obrigatórias e nome da tabela de histórico
+	_, err := golang_migration_system.NewMigrator(golang_migration_system.WithDir(t.TempDir()))
+	assert.EqualError(t, err, "Informe a conexão com o banco de dados com WithDB")
+
+	db, err := golang_migration_system.ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+	assert.NoError(t, err)
+	defer db.Close()
+	_, err = golang_migration_system.NewMigrator(golang_migration_system.WithDB(db))
+	assert.EqualError(t, err, "Informe a origem das migrações com WithSource ou WithDir")
+	_, err = golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir("."),
+		golang_migration_system.WithTableName("migrations; DROP TABLE users"))
+	assert.EqualError(t, err, "Nome de tabela de histórico inválido: migrations; DROP TABLE users")
+
+	// Dois Migrators, cada um com a sua tabela de histórico, o seu lock e o seu log, no mesmo banco de dados
+	var locks []string
+	lock := func(ctx context.Context, db *sql.DB, name string) (func() error, error) {
+		locks = append(locks, name)
+		return func() error { return nil }, nil
+	}
+	usersDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(usersDir, "migration_20240101000000.up.sql"), []byte("CREATE TABLE users (id INTEGER);"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(usersDir, "migration_20240101000000.down.sql"), []byte("DROP TABLE users;"), 0644))
+	var usersLog strings.Builder
+	users, err := golang_migration_system.NewMigrator(
+		golang_migration_system.WithDB(db),
+		golang_migration_system.WithDir(usersDir),
+		golang_migration_system.WithTableName("users_migrations"),
+		golang_migration_system.WithLogger(log.New(&usersLog, "", 0)),
+		golang_migration_system.WithLock(lock),
+	)
+	assert.NoError(t, err)
+
+	billingSrc, err := golang_migration_system.SliceSource(
+		golang_migration_system.SQLMigration{Version: 1, Name: "invoices", Up: "CREATE TABLE invoices (id INTEGER);"},
+		golang_migration_system.SQLMigration{Version: 2, Name: "payments", Up: "CREATE TABLE payments (id INTEGER);"},
+	)
+	assert.NoError(t, err)
+	billing, err := golang_migration_system.NewMigrator(
+		golang_migration_system.WithDB(db),
+		golang_migration_system.WithSource(billingSrc),
+		golang_migration_system.WithTableName("billing_migrations"),
+		golang_migration_system.WithLogger(nil),
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, users.Up(ctx))
+	assert.NoError(t, billing.UpSteps(ctx, 1))
+	assert.Equal(t, []string{"users_migrations"}, locks)
+	assert.Contains(t, usersLog.String(), "Executando migração: migration_20240101000000.up.sql")
+
+	// Cada histórico registra apenas as suas migrações, e a tabela padrão não é criada
+	version, err := users.Version(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20240101000000), version)
+	status, err := billing.Status(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, status, 2) {
+		assert.Equal(t, golang_migration_system.StateApplied, status[0].State)
+		assert.Equal(t, golang_migration_system.StatePending, status[1].State)
+	}
+	_, err = db.Exec("SELECT version FROM schema_migrations")
+	assert.Error(t, err, "A tabela de histórico padrão não deveria ter sido criada")
+
+	// A estrutura lida pelo Migrator não inclui a sua tabela de histórico
+	schemas, err := users.Introspect(ctx)
+	assert.NoError(t, err)
+	var tables []string
+	for _, schema := range schemas {
+		tables = append(tables, schema.TableName)
+	}
+	assert.NotContains(t, tables, "users_migrations")
+	assert.Contains(t, tables, "billing_migrations")
+
+	// A reversão de um Migrator não afeta o outro
+	assert.NoError(t, users.Down(ctx, 1))
+	_, err = db.Exec("SELECT id FROM users")
+	assert.Error(t, err, "A tabela users não foi removida")
+	_, err = db.Exec("SELECT id FROM invoices")
+	assert.NoError(t, err)
+
+	// As migrações são criadas no diretório do Migrator, sem depender de SetMigrationsDir
+	fileName, err := users.CreateMigration("add posts")
+	assert.NoError(t, err)
+	assert.FileExists(t, filepath.Join(usersDir, fileName))
+	_, err = billing.CreateMigration("add refunds")
+	assert.EqualError(t, err, "Informe o diretório de migrações com WithDir para criar migrações")
+}
+
+func TestMigratorsRunConcurrently(t *testing.T) {
+	// Migrators com diretórios e bancos de dados diferentes podem ser usados ao mesmo tempo
+	var wg sync.WaitGroup
+	tables := []string{"users", "posts", "tags", "comments"}
+	errs := make([]error, len(tables))
+	dbs := make([]*sql.DB, len(tables))
+	for i, table := range tables {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "migration_20240101000000.up.sql"), []byte("CREATE TABLE "+table+" (id INTEGER);"), 0644))
+		db, err := golang_migration_system.ConfigDB("sqlite", config.Cfg{Path: filepath.Join(t.TempDir(), "test.db")})
+		assert.NoError(t, err)
+		defer db.Close()
+		dbs[i] = db
+
+		m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(dir), golang_migration_system.WithLogger(nil))
+		assert.NoError(t, err)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = m.Up(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	// Cada banco recebeu apenas a tabela da migração do seu diretório
+	for i, table := range tables {
+		assert.NoError(t, errs[i])
+		_, err := dbs[i].Exec("SELECT id FROM " + table)
+		assert.NoError(t, err, "A tabela %s não foi criada", table)
+		_, err = dbs[i].Exec("SELECT id FROM " + tables[(i+1)%len(tables)])
+		assert.Error(t, err)
+	}
 }
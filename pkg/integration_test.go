@@ -0,0 +1,150 @@
+package golang_migration_system_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	golang_migration_system "github.com/LuisMarchio03/golang_migration_system/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+// integrationDatabases são os bancos de dados dos testes de integração. Cada banco é configurado pelas
+// variáveis de ambiente com a string de conexão do banco testado e a de um banco vazio para a detecção de
+// divergências; sem elas, o banco é ignorado. Na CI, os bancos são criados pelo workflow (veja .github/workflows).
+var integrationDatabases = []struct {
+	driver    string
+	dsnEnv    string
+	shadowEnv string
+}{
+	{"mysql", "MIGRATE_TEST_MYSQL_DSN", "MIGRATE_TEST_MYSQL_SHADOW_DSN"},
+	{"postgresql", "MIGRATE_TEST_POSTGRES_DSN", "MIGRATE_TEST_POSTGRES_SHADOW_DSN"},
+}
+
+// integrationTables são as tabelas criadas pelos testes de integração, na ordem em que podem ser removidas.
+var integrationTables = []string{"orders", "customers", "integration_migrations", "integration_migrations_lock"}
+
+// openIntegrationDB conecta ao banco de dados da variável de ambiente env e remove as tabelas dos testes
+// de integração, antes e depois do teste.
+func openIntegrationDB(t *testing.T, driver string, env string) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(env)
+	if dsn == "" {
+		t.Skipf("Defina %s para executar os testes de integração com %s", env, driver)
+	}
+	db, err := golang_migration_system.ConfigDB(driver, golang_migration_system.Cfg{DSN: dsn})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	drop := func() {
+		for _, table := range integrationTables {
+			_, err := db.Exec("DROP TABLE IF EXISTS " + table)
+			assert.NoError(t, err)
+		}
+	}
+	drop()
+	t.Cleanup(func() {
+		drop()
+		db.Close()
+	})
+	return db
+}
+
+func TestIntegration(t *testing.T) {
+	for _, database := range integrationDatabases {
+		t.Run(database.driver, func(t *testing.T) {
+			db := openIntegrationDB(t, database.driver, database.dsnEnv)
+			shadow := openIntegrationDB(t, database.driver, database.shadowEnv)
+			ctx := context.Background()
+
+			// Migrações geradas no dialeto do banco, com restrições, índices e chave estrangeira
+			customers := golang_migration_system.Schema{
+				DbType:    database.driver,
+				TableName: "customers",
+				Columns: []golang_migration_system.Column{
+					{Name: "id", DataType: golang_migration_system.BigInt, PrimaryKey: true, AutoIncrement: true},
+					{Name: "email", DataType: golang_migration_system.String, Length: 120, Unique: true},
+					{Name: "active", DataType: golang_migration_system.Boolean, Default: "1"},
+					{Name: "created_at", DataType: golang_migration_system.Timestamp, Default: "CURRENT_TIMESTAMP"},
+				},
+			}
+			if database.driver == "postgresql" {
+				customers.Columns[2].Default = "TRUE"
+			}
+			orders := golang_migration_system.Schema{
+				DbType:    database.driver,
+				TableName: "orders",
+				Columns: []golang_migration_system.Column{
+					{Name: "id", DataType: golang_migration_system.BigInt, PrimaryKey: true, AutoIncrement: true},
+					{Name: "customer_id", DataType: golang_migration_system.BigInt},
+					{Name: "total", DataType: golang_migration_system.Decimal, Precision: 10, Scale: 2},
+					{Name: "notes", DataType: golang_migration_system.Text, Nullable: true},
+				},
+				Indexes:     []golang_migration_system.Index{{Columns: []string{"customer_id", "total"}}},
+				Checks:      []golang_migration_system.Check{{Expression: "total >= 0"}},
+				ForeignKeys: []golang_migration_system.ForeignKey{{Columns: []string{"customer_id"}, RefTable: "customers", RefColumns: []string{"id"}, OnDelete: "CASCADE"}},
+			}
+
+			release := make(chan struct{})
+			started := make(chan struct{})
+			seed := golang_migration_system.GoMigration{Version: 1, Up: func(ctx context.Context, tx *sql.Tx) error {
+				close(started)
+				<-release
+				return nil
+			}}
+			dir := t.TempDir()
+			m, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(dir),
+				golang_migration_system.WithTableName("integration_migrations"), golang_migration_system.WithLogger(nil),
+				golang_migration_system.WithGoMigrations(seed))
+			assert.NoError(t, err)
+			_, err = m.GenerateMigration(customers, orders)
+			assert.NoError(t, err)
+
+			// Enquanto uma execução detém o lock do banco, outra aguarda e desiste ao fim do tempo limite
+			done := make(chan error)
+			go func() { done <- m.Up(ctx) }()
+			select {
+			case <-started:
+			case err := <-done:
+				t.Fatalf("As migrações terminaram antes da migração Go: %v", err)
+			}
+			waiting, err := golang_migration_system.NewMigrator(golang_migration_system.WithDB(db), golang_migration_system.WithDir(dir),
+				golang_migration_system.WithTableName("integration_migrations"), golang_migration_system.WithLogger(nil),
+				golang_migration_system.WithGoMigrations(seed), golang_migration_system.WithLockTimeout(time.Second))
+			assert.NoError(t, err)
+			assert.ErrorIs(t, waiting.Up(ctx), golang_migration_system.ErrLockTimeout)
+			close(release)
+			assert.NoError(t, <-done)
+
+			// O histórico registra as migrações aplicadas
+			status, err := m.Status(ctx)
+			assert.NoError(t, err)
+			for _, s := range status {
+				assert.Equal(t, golang_migration_system.StateApplied, s.State, "versão %d", s.Version)
+			}
+
+			// A estrutura lida do catálogo contém as tabelas e não diverge da resultante das migrações
+			schemas, err := m.Introspect(ctx)
+			assert.NoError(t, err)
+			var tables []string
+			for _, schema := range schemas {
+				tables = append(tables, schema.TableName)
+			}
+			assert.Equal(t, []string{"customers", "orders"}, tables)
+			differences, err := m.DetectDrift(ctx, shadow)
+			assert.NoError(t, err)
+			assert.Empty(t, differences)
+
+			// A reversão remove a chave estrangeira, as restrições e as tabelas
+			assert.NoError(t, m.Down(ctx, 1))
+			version, err := m.Version(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), version)
+			_, err = db.Exec("SELECT id FROM orders")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE embedded_users;
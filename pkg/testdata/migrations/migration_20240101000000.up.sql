@@ -0,0 +1 @@
+CREATE TABLE embedded_users (id INTEGER NOT NULL PRIMARY KEY, name TEXT NOT NULL);